@@ -0,0 +1,57 @@
+package pdftoolbox_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCaptureTestdata replaces the pdfToolbox output in testdata with output
+// captured from a licensed installation. It only runs when
+// PDFTOOLBOX_CAPTURE is the path of the pdfToolbox executable,
+// PDFTOOLBOX_CAPTURE_PDF a PDF to check and PDFTOOLBOX_CAPTURE_PROFILE a
+// profile that reports hits for it:
+//
+//	PDFTOOLBOX_CAPTURE=/opt/callas/pdfToolbox \
+//	PDFTOOLBOX_CAPTURE_PDF=flyer.pdf \
+//	PDFTOOLBOX_CAPTURE_PROFILE=preflight.kfpx \
+//	go test -run TestCaptureTestdata .
+//
+// The assertions of the tests reading these files must then be updated to
+// the captured values.
+func TestCaptureTestdata(t *testing.T) {
+	exe := os.Getenv("PDFTOOLBOX_CAPTURE")
+	if exe == "" {
+		t.Skip("PDFTOOLBOX_CAPTURE not set")
+	}
+	pdf, profile := os.Getenv("PDFTOOLBOX_CAPTURE_PDF"), os.Getenv("PDFTOOLBOX_CAPTURE_PROFILE")
+	if pdf == "" || profile == "" {
+		t.Fatal("PDFTOOLBOX_CAPTURE_PDF and PDFTOOLBOX_CAPTURE_PROFILE must be set")
+	}
+
+	testdata, err := filepath.Abs("testdata")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	status, err := exec.Command(exe, "--status").Output()
+	if assert.NoError(t, err) {
+		assert.NoError(t, os.WriteFile(filepath.Join(testdata, "status.txt"), status, 0o644))
+	}
+
+	// Profile runs exit with 1 to 3 when they report hits
+	capture := func(args ...string) {
+		err := exec.Command(exe, args...).Run()
+		if ee, ok := err.(*exec.ExitError); ok && ee.ExitCode() < 100 {
+			err = nil
+		}
+		assert.NoError(t, err, args)
+	}
+
+	capture("--quickcheck", "--format=json", "--outputfile="+filepath.Join(testdata, "quickcheck.json"), pdf)
+	capture("--report=JSON,PATH="+filepath.Join(testdata, "report.json"),
+		"--report=XML,PATH="+filepath.Join(testdata, "report.xml"), profile, pdf)
+}
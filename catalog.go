@@ -0,0 +1,338 @@
+package pdftoolbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrProfileNotFound = errors.New("pdftoolbox: profile not found")
+
+// profileExt is the extension of the profile files a catalog picks up.
+const profileExt = ".kfpx"
+
+// ProfileCatalog caches the enumerated profiles of a folder. Each refresh
+// stats the profile files and checksums those whose size or mtime changed;
+// only files whose content changed are enumerated again by pdfToolbox.
+type ProfileCatalog struct {
+	cl     *Client
+	folder string
+
+	// refreshMu serialises refreshes; readers only take mu
+	refreshMu sync.Mutex
+	checkedAt time.Time
+	lastErr   error
+
+	mu    sync.RWMutex
+	files map[string]*catalogFile
+	info  Information
+}
+
+type catalogFile struct {
+	size    int64
+	modTime time.Time
+	sum     [sha256.Size]byte
+	// profile is nil when pdfToolbox did not report the file, e.g. because
+	// it is not a valid profile
+	profile *Profiles
+}
+
+// Catalog returns the catalog of the profiles in folder, or in the client's
+// profile folder when folder is empty. Catalogs are kept for the lifetime of
+// the client, so repeated calls share one cache.
+func (cl *Client) Catalog(folder string) (*ProfileCatalog, error) {
+	if folder == "" {
+		if cl.profileFolder == nil {
+			return nil, ErrNoProfileFolder
+		}
+		folder = *cl.profileFolder
+	}
+
+	abs, err := filepath.Abs(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	cl.catalogsMu.Lock()
+	defer cl.catalogsMu.Unlock()
+
+	if c, ok := cl.catalogs[abs]; ok {
+		return c, nil
+	}
+
+	if cl.catalogs == nil {
+		cl.catalogs = make(map[string]*ProfileCatalog)
+	}
+	c := &ProfileCatalog{cl: cl, folder: abs, files: make(map[string]*catalogFile)}
+	cl.catalogs[abs] = c
+
+	return c, nil
+}
+
+// Folder returns the absolute path of the catalogued folder.
+func (c *ProfileCatalog) Folder() string {
+	return c.folder
+}
+
+// Refresh brings the catalog up to date with the profile folder. Callers
+// that arrive while a refresh is running wait for it and share its result.
+func (c *ProfileCatalog) Refresh(ctx context.Context) error {
+	start := time.Now()
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.checkedAt.After(start) {
+		return c.lastErr
+	}
+
+	c.lastErr = c.refresh(ctx)
+	c.checkedAt = time.Now()
+
+	return c.lastErr
+}
+
+func (c *ProfileCatalog) refresh(ctx context.Context) error {
+	scanned, err := c.scan()
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	old := c.files
+	c.mu.RUnlock()
+
+	files := make(map[string]*catalogFile, len(scanned))
+	var changed []string
+
+	for path, info := range scanned {
+		prev := old[path]
+		if prev != nil && prev.size == info.Size() && prev.modTime.Equal(info.ModTime()) {
+			files[path] = prev
+			continue
+		}
+
+		sum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+
+		f := &catalogFile{size: info.Size(), modTime: info.ModTime(), sum: sum}
+		if prev != nil && prev.sum == sum {
+			// Touched but not modified
+			f.profile = prev.profile
+		} else {
+			changed = append(changed, path)
+		}
+		files[path] = f
+	}
+
+	info := c.info
+	if len(changed) > 0 {
+		c.cl.logger.Debug("enumerating changed profiles", "folder", c.folder, "count", len(changed))
+
+		resp, err := c.cl.enumerateFiles(ctx, changed)
+		if err != nil {
+			return err
+		}
+
+		info = resp.Information
+		for _, p := range resp.Profiles {
+			if f, ok := files[p.Path]; ok {
+				f.profile = &p
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.files = files
+	c.info = info
+	c.mu.Unlock()
+
+	return nil
+}
+
+// scan returns the profile files under the folder.
+func (c *ProfileCatalog) scan() (map[string]fs.FileInfo, error) {
+	scanned := make(map[string]fs.FileInfo)
+
+	err := filepath.WalkDir(c.folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), profileExt) {
+			return nil
+		}
+
+		// Stat rather than d.Info so that symlinked profiles are followed
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			scanned[path] = info
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return scanned, nil
+}
+
+// enumerateFiles runs EnumerateProfiles on a temporary folder holding links
+// to paths, and reports the profiles under their original paths.
+func (cl *Client) enumerateFiles(ctx context.Context, paths []string) (*EnumerateProfilesResponse, error) {
+	dir, err := os.MkdirTemp("", "pdftoolbox-catalog")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// Link names are prefixed so that profiles with the same name in
+	// different subfolders do not collide
+	links := make(map[string]string, len(paths))
+	for i, path := range paths {
+		name := fmt.Sprintf("%d-%s", i, filepath.Base(path))
+		if err := linkOrCopy(path, filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+		links[name] = path
+	}
+
+	resp, err := cl.EnumerateProfilesContext(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := resp.Profiles[:0]
+	for _, p := range resp.Profiles {
+		if path, ok := links[filepath.Base(p.Path)]; ok {
+			p.Path = path
+		} else if !slices.Contains(paths, p.Path) {
+			// Neither the link nor the resolved target
+			continue
+		}
+		profiles = append(profiles, p)
+	}
+	resp.Profiles = profiles
+
+	return resp, nil
+}
+
+// Response returns the catalogued profiles as EnumerateProfiles would,
+// sorted by path, refreshing the catalog first.
+func (c *ProfileCatalog) Response(ctx context.Context) (*EnumerateProfilesResponse, error) {
+	if err := c.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	resp := &EnumerateProfilesResponse{Information: c.info}
+	for _, path := range c.paths() {
+		if p := c.files[path].profile; p != nil {
+			resp.Profiles = append(resp.Profiles, cloneProfile(p))
+		}
+	}
+
+	return resp, nil
+}
+
+// Lookup returns the profile called key, or else the profile at path key,
+// relative to the catalogued folder unless absolute. The catalog is
+// refreshed first. When several profiles share a name, the one with the
+// first path wins.
+func (c *ProfileCatalog) Lookup(ctx context.Context, key string) (Profiles, error) {
+	if err := c.Refresh(ctx); err != nil {
+		return Profiles{}, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, path := range c.paths() {
+		if p := c.files[path].profile; p != nil && p.Name == key {
+			return cloneProfile(p), nil
+		}
+	}
+
+	path := key
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.folder, path)
+	}
+	if f, ok := c.files[filepath.Clean(path)]; ok && f.profile != nil {
+		return cloneProfile(f.profile), nil
+	}
+
+	return Profiles{}, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, key, c.folder)
+}
+
+// paths returns the catalogued paths in order. c.mu must be held.
+func (c *ProfileCatalog) paths() []string {
+	paths := make([]string, 0, len(c.files))
+	for path := range c.files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func cloneProfile(p *Profiles) Profiles {
+	cp := *p
+	cp.Variables = slices.Clone(p.Variables)
+	return cp
+}
+
+func fileChecksum(path string) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+
+	f, err := os.Open(path)
+	if err != nil {
+		return sum, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return sum, err
+	}
+	copy(sum[:], h.Sum(nil))
+
+	return sum, nil
+}
+
+// linkOrCopy symlinks dst to src, copying the file where symlinks are not
+// available.
+func linkOrCopy(src, dst string) error {
+	if err := os.Symlink(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
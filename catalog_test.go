@@ -0,0 +1,159 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// enumerateFolder answers --enumprofiles with a profile for each file in
+// the enumerated folder, named after the file content and declaring vars,
+// and records the profile names it was given.
+func enumerateFolder(enumerated *[][]string, vars ...pdftoolbox.Variables) pdftoolboxtest.Response {
+	return pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\nDuration\t00:00\n",
+		Run: func(args []string) error {
+			dir := args[len(args)-2]
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				return err
+			}
+
+			var resp pdftoolbox.EnumerateProfilesResponse
+			var names []string
+			for _, e := range entries {
+				b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+				if err != nil {
+					return err
+				}
+				resp.Profiles = append(resp.Profiles, pdftoolbox.Profiles{Name: string(b), Path: filepath.Join(dir, e.Name()), Variables: vars})
+				names = append(names, string(b))
+			}
+			*enumerated = append(*enumerated, names)
+
+			b, err := json.Marshal(resp)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(args[len(args)-1], b, 0o644)
+		},
+	}
+}
+
+func writeProfile(t *testing.T, path string, name string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(name), 0o644))
+}
+
+func TestCatalog(t *testing.T) {
+	dir := t.TempDir()
+	writeProfile(t, filepath.Join(dir, "fix.kfpx"), "Fix")
+	writeProfile(t, filepath.Join(dir, "print", "x4.kfpx"), "Convert to PDF/X-4")
+	writeProfile(t, filepath.Join(dir, "notes.txt"), "Notes")
+
+	var enumerated [][]string
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = enumerateFolder(&enumerated)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, ProfileFolder: &dir})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	catalog, err := cl.Catalog("")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	same, _ := cl.Catalog(dir)
+	assert.Same(t, catalog, same)
+
+	ctx := context.Background()
+	resp, err := catalog.Response(ctx)
+	if !assert.NoError(t, err) || !assert.Len(t, resp.Profiles, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, filepath.Join(dir, "fix.kfpx"), resp.Profiles[0].Path)
+	assert.Equal(t, filepath.Join(dir, "print", "x4.kfpx"), resp.Profiles[1].Path)
+
+	p, err := catalog.Lookup(ctx, "Convert to PDF/X-4")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "print", "x4.kfpx"), p.Path)
+
+	p, err = catalog.Lookup(ctx, "fix.kfpx")
+	assert.NoError(t, err)
+	assert.Equal(t, "Fix", p.Name)
+	assert.Len(t, enumerated, 1)
+
+	// Touched without changes is not enumerated again
+	later := time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(dir, "fix.kfpx"), later, later))
+	_, err = catalog.Lookup(ctx, "Fix")
+	assert.NoError(t, err)
+	assert.Len(t, enumerated, 1)
+
+	// Only the changed profile is enumerated
+	writeProfile(t, filepath.Join(dir, "fix.kfpx"), "Fix v2")
+	p, err = catalog.Lookup(ctx, "Fix v2")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "fix.kfpx"), p.Path)
+	if assert.Len(t, enumerated, 2) {
+		assert.Equal(t, []string{"Fix v2"}, enumerated[1])
+	}
+
+	assert.NoError(t, os.Remove(filepath.Join(dir, "print", "x4.kfpx")))
+	_, err = catalog.Lookup(ctx, "Convert to PDF/X-4")
+	assert.ErrorIs(t, err, pdftoolbox.ErrProfileNotFound)
+	assert.Len(t, enumerated, 2)
+}
+
+func TestCatalogConcurrentLookups(t *testing.T) {
+	dir := t.TempDir()
+	writeProfile(t, filepath.Join(dir, "fix.kfpx"), "Fix")
+
+	var enumerated [][]string
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = enumerateFolder(&enumerated)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	catalog, err := cl.Catalog(dir)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := catalog.Lookup(context.Background(), "Fix")
+			assert.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, "fix.kfpx"), p.Path)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, enumerated, 1)
+}
+
+func TestCatalogNoProfileFolder(t *testing.T) {
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: pdftoolboxtest.NewExecutor()})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.Catalog("")
+	assert.ErrorIs(t, err, pdftoolbox.ErrNoProfileFolder)
+}
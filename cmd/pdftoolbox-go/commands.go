@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+func runCommand(ctx context.Context, args []string) error {
+	flags, g := newFlagSet("run")
+	var vars varFlags
+	flags.Var(&vars, "var", "set a profile variable as key=value (repeatable)")
+	outputFolder := flags.String("outputfolder", "", "folder output files are written to")
+	overwrite := flags.Bool("overwrite", false, "overwrite existing output files")
+	timeout := flags.Duration("timeout", 0, "stop pdfToolbox after this long")
+	report := flags.String("report", "", "write a JSON report to this path")
+	progress := flags.Bool("progress", false, "print progress to stderr while the job runs")
+	asJSON := flags.Bool("json", false, "print the output as JSON")
+
+	cfg, err := parseFlags(flags, g, args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() < 2 {
+		return errUsage
+	}
+
+	cl, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	runArgs := vars.args()
+	if *outputFolder != "" {
+		runArgs = append(runArgs, pdftoolbox.NewOutputFolderArg(*outputFolder))
+	}
+	if *overwrite {
+		runArgs = append(runArgs, pdftoolbox.NewOverwriteArg())
+	}
+	if *timeout > 0 {
+		runArgs = append(runArgs, pdftoolbox.NewTimeoutArg(*timeout))
+	}
+	if *report != "" {
+		runArgs = append(runArgs, pdftoolbox.NewReportArg(pdftoolbox.ReportJSON, *report))
+	}
+
+	var onEvent pdftoolbox.EventHandler
+	if *progress {
+		onEvent = func(ev pdftoolbox.Event) {
+			switch ev.Type {
+			case pdftoolbox.ProgressEvent:
+				fmt.Fprintf(os.Stderr, "%s %d%%\n", ev.Step, ev.Progress)
+			case pdftoolbox.RetryEvent:
+				fmt.Fprintf(os.Stderr, "retrying (attempt %d): %v\n", ev.Attempt, ev.Err)
+			}
+		}
+	}
+
+	out, err := cl.RunProfileStream(ctx, flags.Arg(0), flags.Args()[1:], onEvent, runArgs...)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(out)
+	}
+	return printRun(flags.Arg(0), out)
+}
+
+func printRun(profile string, out pdftoolbox.CmdOutput) error {
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Profile\t%s\n", profile)
+	fmt.Fprintf(w, "Verdict\t%s\n", out.Summary.Verdict())
+	fmt.Fprintf(w, "Duration\t%s\n", out.Duration.Round(time.Millisecond))
+	if out.Satellite != "" {
+		fmt.Fprintf(w, "Satellite\t%s\n", out.Satellite)
+	}
+
+	if len(out.Steps) > 0 {
+		fmt.Fprintln(w, "\nSTEP\tERRORS\tWARNINGS\tINFOS\tFIXES")
+		for _, s := range out.Steps {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", s.Name, s.Summary.Errors, s.Summary.Warnings, s.Summary.Infos, s.Summary.Corrections)
+		}
+	}
+
+	if len(out.Hits) > 0 {
+		fmt.Fprintln(w, "\nSEVERITY\tMESSAGE")
+		for _, h := range out.Hits {
+			fmt.Fprintf(w, "%s\t%s\n", h.Severity, h.Message)
+		}
+	}
+
+	if len(out.Artifacts) > 0 {
+		fmt.Fprintln(w, "\nOUTPUT\tSTEP")
+		for _, a := range out.Artifacts {
+			fmt.Fprintf(w, "%s\t%s\n", a.Path, a.Step)
+		}
+	}
+
+	return w.Flush()
+}
+
+func profilesCommand(ctx context.Context, args []string) error {
+	flags, g := newFlagSet("profiles")
+	asJSON := flags.Bool("json", false, "print the profiles as JSON")
+
+	cfg, err := parseFlags(flags, g, args)
+	if err != nil {
+		return err
+	}
+
+	folder := cfg.ProfileFolder
+	if flags.NArg() > 0 {
+		folder = flags.Arg(0)
+	}
+	if folder == "" || flags.NArg() > 1 {
+		return errUsage
+	}
+
+	cl, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := cl.Catalog(folder)
+	if err != nil {
+		return err
+	}
+	resp, err := catalog.Response(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(resp)
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPATH\tVARIABLES")
+	for _, p := range resp.Profiles {
+		keys := make([]string, 0, len(p.Variables))
+		for _, v := range p.Variables {
+			keys = append(keys, v.Key)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.Name, p.Path, strings.Join(keys, ", "))
+	}
+	return w.Flush()
+}
+
+func quickCheckCommand(ctx context.Context, args []string) error {
+	flags, g := newFlagSet("quickcheck")
+	password := flags.String("password", "", "password of an encrypted file")
+	asJSON := flags.Bool("json", false, "print the result as JSON")
+
+	cfg, err := parseFlags(flags, g, args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errUsage
+	}
+
+	cl, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cl.QuickCheck(ctx, flags.Arg(0), &pdftoolbox.QuickCheckOpts{Password: *password})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(resp)
+	}
+
+	doc := resp.Document
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "PDF version\t%s\n", doc.PDFVersion)
+	fmt.Fprintf(w, "Pages\t%d\n", doc.PageCount)
+	fmt.Fprintf(w, "Encrypted\t%t\n", doc.Encrypted)
+	fmt.Fprintf(w, "Standards\t%s\n", strings.Join(doc.Standards, ", "))
+	fmt.Fprintf(w, "Colour spaces\t%s\n", strings.Join(resp.ColorSpaces, ", "))
+	if resp.OutputIntent != nil {
+		fmt.Fprintf(w, "Output intent\t%s\n", resp.OutputIntent.OutputConditionIdentifier)
+	}
+
+	fmt.Fprintln(w, "\nPAGE\tMEDIA (mm)\tTRIM (mm)\tROTATION")
+	for _, p := range resp.Pages {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", p.Number, boxSize(p.MediaBox), boxSize(p.TrimBox), p.Rotation)
+	}
+
+	if len(resp.Fonts) > 0 {
+		fmt.Fprintln(w, "\nFONT\tTYPE\tEMBEDDED")
+		for _, f := range resp.Fonts {
+			fmt.Fprintf(w, "%s\t%s\t%t\n", f.Name, f.Type, f.Embedded)
+		}
+	}
+
+	return w.Flush()
+}
+
+func boxSize(b *pdftoolbox.PageBox) string {
+	if b == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f x %.1f", b.WidthMM, b.HeightMM)
+}
+
+func licenseCommand(ctx context.Context, args []string) error {
+	flags, g := newFlagSet("license")
+	asJSON := flags.Bool("json", false, "print the status as JSON")
+
+	cfg, err := parseFlags(flags, g, args)
+	if err != nil {
+		return err
+	}
+
+	cl, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	switch flags.Arg(0) {
+	case "", "status":
+		status, err := cl.LicenseStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(status)
+		}
+
+		w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Product\t%s\n", status.Product)
+		fmt.Fprintf(w, "Version\t%s\n", status.Version)
+		fmt.Fprintf(w, "Activated\t%t\n", status.Activated)
+		if !status.Expires.IsZero() {
+			fmt.Fprintf(w, "Expires\t%s\n", status.Expires.Format(time.DateOnly))
+		}
+		if status.Seats > 0 {
+			fmt.Fprintf(w, "Seats\t%d\n", status.Seats)
+		}
+		if status.LicenseServer != "" {
+			fmt.Fprintf(w, "Licence server\t%s\n", status.LicenseServer)
+		}
+		return w.Flush()
+	case "activate":
+		if flags.NArg() != 4 {
+			return errUsage
+		}
+		return cl.Activate(ctx, flags.Arg(1), flags.Arg(2), flags.Arg(3))
+	case "deactivate":
+		return cl.Deactivate(ctx)
+	}
+
+	return errUsage
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeToolbox makes the commands run exe instead of pdfToolbox and print to
+// the returned buffer.
+func fakeToolbox(t *testing.T, exe *pdftoolboxtest.Executor) *bytes.Buffer {
+	t.Helper()
+
+	t.Setenv("PDFTOOLBOX_CONFIG", filepath.Join(t.TempDir(), "missing.json"))
+
+	var buf bytes.Buffer
+	prevExecutor, prevStdout := executor, stdout
+	executor, stdout = exe, &buf
+	t.Cleanup(func() { executor, stdout = prevExecutor, prevStdout })
+
+	return &buf
+}
+
+func TestRunCommand(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\nStep\tPreflight\nHit\tError\tFont not embedded\nSummary\tErrors\t1\nDuration\t00:01\n",
+	}
+	buf := fakeToolbox(t, exe)
+
+	err := runCommand(context.Background(), []string{"--var", "trimWidth=55", "--overwrite", "preflight.kfpx", "in.pdf"})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	args := exe.LastCall()
+	assert.Contains(t, args, "--setvariable=trimWidth:55")
+	assert.Contains(t, args, "--overwrite")
+	assert.Contains(t, args, "preflight.kfpx")
+	assert.Contains(t, args, "in.pdf")
+
+	out := buf.String()
+	assert.Contains(t, out, "Profile   preflight.kfpx")
+	assert.Contains(t, out, "Verdict   errors")
+	assert.Contains(t, out, "Preflight  1")
+	assert.Contains(t, out, "Error     Font not embedded")
+}
+
+func TestRunCommandJSON(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\nSummary\tErrors\t0\n"}
+	buf := fakeToolbox(t, exe)
+
+	err := runCommand(context.Background(), []string{"--json", "preflight.kfpx", "in.pdf"})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var out pdftoolbox.CmdOutput
+	if assert.NoError(t, json.Unmarshal(buf.Bytes(), &out)) {
+		assert.Equal(t, pdftoolbox.VerdictPass, out.Summary.Verdict())
+	}
+}
+
+func TestRunCommandUsage(t *testing.T) {
+	fakeToolbox(t, pdftoolboxtest.NewExecutor())
+
+	err := runCommand(context.Background(), []string{"preflight.kfpx"})
+	assert.ErrorIs(t, err, errUsage)
+}
+
+func TestWatchCommand(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\nSummary\tErrors\t0\n"}
+	fakeToolbox(t, exe)
+
+	input := t.TempDir()
+	if !assert.NoError(t, os.WriteFile(filepath.Join(input, "in.pdf"), []byte("%PDF-1.7"), 0o644)) {
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- watchCommand(ctx, []string{
+			"-input", input, "-profile", "/profiles/preflight.kfpx", "-var", "trimWidth=55",
+			"-interval", "10ms", "-stable", "10ms",
+		})
+	}()
+
+	assert.Eventually(t, func() bool { return len(exe.Calls()) > 0 }, 2*time.Second, 10*time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+
+	args := exe.LastCall()
+	assert.Contains(t, args, "--setvariable=trimWidth:55")
+	assert.Contains(t, args, "/profiles/preflight.kfpx")
+}
+
+func TestWatchCommandNeedsProfile(t *testing.T) {
+	fakeToolbox(t, pdftoolboxtest.NewExecutor())
+
+	err := watchCommand(context.Background(), []string{"-input", t.TempDir()})
+	assert.ErrorContains(t, err, "-input needs -profile")
+
+	err = watchCommand(context.Background(), nil)
+	assert.ErrorContains(t, err, "no folders to watch")
+}
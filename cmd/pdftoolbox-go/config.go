@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/hotfolder"
+)
+
+// config holds the settings shared by all subcommands. Values come from the
+// config file, then the environment, then flags, each overriding the last.
+type config struct {
+	Exe           string `json:"exe"`
+	ProfileFolder string `json:"profileFolder"`
+	CacheFolder   string `json:"cacheFolder"`
+	LicenseServer string `json:"licenseServer"`
+	Concurrency   int    `json:"concurrency"`
+	WorkDir       string `json:"workDir"`
+	Addr          string `json:"addr"`
+	GRPCAddr      string `json:"grpcAddr"`
+	Verbose       bool   `json:"verbose"`
+	// Folders are the hot folders run by the watch command
+	Folders []hotfolder.Folder `json:"folders"`
+}
+
+var envVars = map[string]func(c *config, v string) error{
+	"PDFTOOLBOX_EXE":           func(c *config, v string) error { c.Exe = v; return nil },
+	"PDFTOOLBOX_PROFILES":      func(c *config, v string) error { c.ProfileFolder = v; return nil },
+	"PDFTOOLBOX_CACHE":         func(c *config, v string) error { c.CacheFolder = v; return nil },
+	"PDFTOOLBOX_LICENSESERVER": func(c *config, v string) error { c.LicenseServer = v; return nil },
+	"PDFTOOLBOX_WORKDIR":       func(c *config, v string) error { c.WorkDir = v; return nil },
+	"PDFTOOLBOX_ADDR":          func(c *config, v string) error { c.Addr = v; return nil },
+	"PDFTOOLBOX_GRPC_ADDR":     func(c *config, v string) error { c.GRPCAddr = v; return nil },
+	"PDFTOOLBOX_CONCURRENCY": func(c *config, v string) error {
+		n, err := strconv.Atoi(v)
+		c.Concurrency = n
+		return err
+	},
+}
+
+func defaultConfig() config {
+	return config{
+		Exe:         "pdfToolbox",
+		Concurrency: 1,
+		Addr:        ":8080",
+	}
+}
+
+// defaultConfigPath is $PDFTOOLBOX_CONFIG, or config.json in the user's
+// config folder.
+func defaultConfigPath() string {
+	if p := os.Getenv("PDFTOOLBOX_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pdftoolbox-go", "config.json")
+}
+
+// loadConfig reads the config file at path and applies the environment.
+// A missing file is only an error when the path was given explicitly.
+func loadConfig(path string, explicit bool, getenv func(string) string) (config, error) {
+	cfg := defaultConfig()
+
+	if path != "" {
+		b, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(b, &cfg); err != nil {
+				return cfg, fmt.Errorf("config %s: %w", path, err)
+			}
+		case errors.Is(err, fs.ErrNotExist) && !explicit:
+		default:
+			return cfg, err
+		}
+	}
+
+	for name, set := range envVars {
+		if v := getenv(name); v != "" {
+			if err := set(&cfg, v); err != nil {
+				return cfg, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// globalFlags registers the flags every subcommand accepts. They are applied
+// to the loaded config by parseFlags.
+type globalFlags struct {
+	configPath    string
+	exe           string
+	profileFolder string
+	licenseServer string
+	verbose       bool
+}
+
+func (g *globalFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&g.configPath, "config", "", "config file (default $PDFTOOLBOX_CONFIG or the user config folder)")
+	flags.StringVar(&g.exe, "exe", "", "path to the pdfToolbox executable")
+	flags.StringVar(&g.profileFolder, "profiles", "", "folder profiles are looked up in")
+	flags.StringVar(&g.licenseServer, "licenseserver", "", "licence server to take seats from")
+	flags.BoolVar(&g.verbose, "v", false, "log the commands that are run")
+}
+
+// parseFlags parses args with flags and returns the resulting config.
+func parseFlags(flags *flag.FlagSet, g *globalFlags, args []string) (config, error) {
+	if err := flags.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	path, explicit := g.configPath, g.configPath != ""
+	if !explicit {
+		path = defaultConfigPath()
+	}
+
+	cfg, err := loadConfig(path, explicit, os.Getenv)
+	if err != nil {
+		return cfg, err
+	}
+
+	if g.exe != "" {
+		cfg.Exe = g.exe
+	}
+	if g.profileFolder != "" {
+		cfg.ProfileFolder = g.profileFolder
+	}
+	if g.licenseServer != "" {
+		cfg.LicenseServer = g.licenseServer
+	}
+	if g.verbose {
+		cfg.Verbose = true
+	}
+
+	return cfg, nil
+}
+
+// varFlags collects repeated --var key=value flags. A key given again
+// replaces the earlier value.
+type varFlags map[string]string
+
+func (v *varFlags) String() string {
+	return fmt.Sprint(len(*v), " variables")
+}
+
+func (v *varFlags) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("variable %q is not key=value", s)
+	}
+	if *v == nil {
+		*v = varFlags{}
+	}
+	(*v)[key] = value
+	return nil
+}
+
+// args returns the variables as --setvariable args, sorted by key.
+func (v varFlags) args() []pdftoolbox.Arg {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	args := make([]pdftoolbox.Arg, 0, len(keys))
+	for _, k := range keys {
+		args = append(args, pdftoolbox.NewSetVariableArg(k, v[k]))
+	}
+	return args
+}
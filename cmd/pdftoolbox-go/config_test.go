@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(path, []byte(`{"exe": "/opt/callas/pdfToolbox", "profileFolder": "/profiles", "concurrency": 4}`), 0o644)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	env := map[string]string{"PDFTOOLBOX_PROFILES": "/env/profiles"}
+	cfg, err := loadConfig(path, true, func(k string) string { return env[k] })
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "/opt/callas/pdfToolbox", cfg.Exe)
+	assert.Equal(t, "/env/profiles", cfg.ProfileFolder)
+	assert.Equal(t, 4, cfg.Concurrency)
+	assert.Equal(t, ":8080", cfg.Addr)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.json"), true, os.Getenv)
+	assert.Error(t, err)
+
+	cfg, err = loadConfig(filepath.Join(t.TempDir(), "missing.json"), false, func(string) string { return "" })
+	assert.NoError(t, err)
+	assert.Equal(t, defaultConfig(), cfg)
+}
+
+func TestVarFlags(t *testing.T) {
+	var vars varFlags
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&vars, "var", "")
+
+	err := flags.Parse([]string{"--var", "trimWidth=50", "--var", "note=a=b", "--var", "trimWidth=55"})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, varFlags{"trimWidth": "55", "note": "a=b"}, vars)
+
+	args := vars.args()
+	if assert.Len(t, args, 2) {
+		assert.Equal(t, "--setvariable=note:a=b", args[0].ArgString())
+		assert.Equal(t, "--setvariable=trimWidth:55", args[1].ArgString())
+	}
+
+	assert.Error(t, flags.Parse([]string{"--var", "nokey"}))
+}
@@ -0,0 +1,133 @@
+// Command pdftoolbox-go runs pdfToolbox jobs from the command line.
+//
+// Usage:
+//
+//	pdftoolbox-go <command> [flags] [args]
+//
+// The commands are run, profiles, quickcheck, license, serve, which serves
+// the HTTP and gRPC APIs, and watch, which runs the hot folders of the
+// config file.
+//
+// Settings are read from a JSON config file, then PDFTOOLBOX_* environment
+// variables, then flags. Results are printed as tables, or as JSON with
+// -json.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"run":        {"run [flags] PROFILE INPUT...", runCommand},
+	"profiles":   {"profiles [flags] [FOLDER]", profilesCommand},
+	"quickcheck": {"quickcheck [flags] FILE", quickCheckCommand},
+	"license":    {"license [flags] [status | activate NAME COMPANY KEY | deactivate]", licenseCommand},
+	"serve":      {"serve [flags]", serveCommand},
+	"watch":      {"watch [flags]", watchCommand},
+}
+
+// stdout is where results are printed.
+var stdout io.Writer = os.Stdout
+
+// executor runs pdfToolbox. Nil uses the real binary.
+var executor pdftoolbox.PDFToolboxExecutor
+
+// errUsage makes main print the usage of the command.
+var errUsage = errors.New("usage")
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := cmd.run(ctx, os.Args[2:])
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "usage: pdftoolbox-go %s\n", cmd.usage)
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: pdftoolbox-go <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *globalFlags) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	g := &globalFlags{}
+	g.register(flags)
+	return flags, g
+}
+
+func newClient(cfg config) (*pdftoolbox.Client, error) {
+	level := slog.LevelWarn
+	if cfg.Verbose {
+		level = slog.LevelDebug
+	}
+
+	opts := &pdftoolbox.ClientOpts{
+		Executor: executor,
+		Logger:   slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})),
+	}
+	if cfg.ProfileFolder != "" {
+		opts.ProfileFolder = &cfg.ProfileFolder
+	}
+	if cfg.CacheFolder != "" {
+		opts.CacheFolder = &cfg.CacheFolder
+	}
+	if cfg.LicenseServer != "" {
+		opts.LicenseServer = &cfg.LicenseServer
+	}
+
+	return pdftoolbox.New(cfg.Exe, opts)
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/fikastudio/pdftoolbox-go/internal/serve"
+)
+
+func serveCommand(ctx context.Context, args []string) error {
+	flags, g := newFlagSet("serve")
+	addr := flags.String("addr", "", "address to serve HTTP on (default :8080)")
+	grpcAddr := flags.String("grpc-addr", "", "address to serve gRPC on (default: disabled)")
+	concurrency := flags.Int("concurrency", 0, "number of jobs run at once, usually the number of licence seats")
+	workDir := flags.String("workdir", "", "folder for uploads and outputs")
+
+	cfg, err := parseFlags(flags, g, args)
+	if err != nil {
+		return err
+	}
+	if *addr != "" {
+		cfg.Addr = *addr
+	}
+	if *grpcAddr != "" {
+		cfg.GRPCAddr = *grpcAddr
+	}
+	if *concurrency > 0 {
+		cfg.Concurrency = *concurrency
+	}
+	if *workDir != "" {
+		cfg.WorkDir = *workDir
+	}
+
+	cl, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	return serve.Run(ctx, cl, &serve.Opts{
+		Addr:          cfg.Addr,
+		GRPCAddr:      cfg.GRPCAddr,
+		ProfileFolder: cfg.ProfileFolder,
+		WorkDir:       cfg.WorkDir,
+		Concurrency:   cfg.Concurrency,
+		Logger:        slog.New(slog.NewTextHandler(os.Stderr, nil)),
+	})
+}
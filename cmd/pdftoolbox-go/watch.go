@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/fikastudio/pdftoolbox-go/hotfolder"
+)
+
+// watchCommand runs the hot folders of the config file, or the single folder
+// given with -input and -profile.
+func watchCommand(ctx context.Context, args []string) error {
+	flags, g := newFlagSet("watch")
+	input := flags.String("input", "", "folder to watch instead of the configured folders")
+	output := flags.String("output", "", "folder for the processing, success and error folders (default: the input folder)")
+	profile := flags.String("profile", "", "profile run on files dropped into -input")
+	var vars varFlags
+	flags.Var(&vars, "var", "set a profile variable as key=value (repeatable)")
+	interval := flags.Duration("interval", 0, "how often folders are scanned (default 2s)")
+	stableFor := flags.Duration("stable", 0, "how long a file must stay unchanged before it is picked up (default 5s)")
+	concurrency := flags.Int("concurrency", 0, "number of jobs run at once")
+
+	cfg, err := parseFlags(flags, g, args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errUsage
+	}
+
+	folders := cfg.Folders
+	if *input != "" {
+		if *profile == "" {
+			return fmt.Errorf("-input needs -profile")
+		}
+		folders = []hotfolder.Folder{{Input: *input, Output: *output, Profile: *profile, Variables: vars}}
+	}
+	if len(folders) == 0 {
+		return fmt.Errorf("no folders to watch: configure folders or pass -input and -profile")
+	}
+
+	if *concurrency > 0 {
+		cfg.Concurrency = *concurrency
+	}
+
+	cl, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	w, err := hotfolder.New(cl, folders, &hotfolder.Opts{
+		Interval:    *interval,
+		StableFor:   *stableFor,
+		Concurrency: cfg.Concurrency,
+		Logger:      slog.New(slog.NewTextHandler(os.Stderr, nil)),
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Run(ctx)
+}
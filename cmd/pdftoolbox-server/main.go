@@ -0,0 +1,56 @@
+// Command pdftoolbox-server serves a pdfToolbox installation over HTTP and,
+// with -grpc-addr, gRPC. See packages server and grpcserver for the APIs.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/internal/serve"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	grpcAddr := flag.String("grpc-addr", "", "address to serve gRPC on (default: disabled)")
+	exe := flag.String("exe", "pdfToolbox", "path to the pdfToolbox executable")
+	profiles := flag.String("profiles", "", "folder holding the profiles jobs may run")
+	workDir := flag.String("workdir", "", "folder for uploads and outputs (default: in the temp folder)")
+	concurrency := flag.Int("concurrency", 1, "number of jobs run at once, usually the number of licence seats")
+	licenseServer := flag.String("licenseserver", "", "licence server to take seats from")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	opts := &pdftoolbox.ClientOpts{Logger: logger}
+	if *licenseServer != "" {
+		opts.LicenseServer = licenseServer
+	}
+
+	cl, err := pdftoolbox.New(*exe, opts)
+	if err != nil {
+		logger.Error("creating client", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Both APIs share one pool so together they never exceed -concurrency
+	err = serve.Run(ctx, cl, &serve.Opts{
+		Addr:          *addr,
+		GRPCAddr:      *grpcAddr,
+		ProfileFolder: *profiles,
+		WorkDir:       *workDir,
+		Concurrency:   *concurrency,
+		Logger:        logger,
+	})
+	if err != nil {
+		logger.Error("serving", "error", err)
+		os.Exit(1)
+	}
+}
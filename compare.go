@@ -0,0 +1,121 @@
+package pdftoolbox
+
+import (
+	"context"
+	"strconv"
+)
+
+type CompareOpts struct {
+	// Resolution in dpi used for the visual comparison
+	Resolution int
+	// Threshold is the fraction of differing pixels below which a page is
+	// considered visually equal
+	Threshold float64
+	// ReportPDF, when set, is where the diff report PDF is written
+	ReportPDF string
+}
+
+type CompareResult struct {
+	// Identical is true when no page differs
+	Identical bool       `json:"identical"`
+	Pages     []PageDiff `json:"pages"`
+	// ReportPath is the diff report PDF, if one was requested
+	ReportPath string `json:"report_path"`
+}
+
+// PageDiff lists the differences found on a page. Pages only present in one
+// of the documents have MissingIn set to "a" or "b".
+type PageDiff struct {
+	Page       int         `json:"page"`
+	MissingIn  string      `json:"missing_in,omitempty"`
+	TextDiffs  []TextDiff  `json:"text_diffs"`
+	PixelDelta float64     `json:"pixel_delta"`
+	BoxChanges []BoxChange `json:"box_changes"`
+	// Visual is set when PixelDelta exceeds CompareOpts.Threshold
+	Visual bool `json:"visual"`
+	// Regions are the areas that differ visually, in PDF points as left,
+	// bottom, right, top
+	Regions [][4]float64 `json:"regions"`
+}
+
+// TextChanged reports whether the text of the page differs.
+func (d PageDiff) TextChanged() bool {
+	return len(d.TextDiffs) > 0
+}
+
+// Changed reports whether the page differs in any way.
+func (d PageDiff) Changed() bool {
+	return d.MissingIn != "" || d.TextChanged() || d.Visual || len(d.BoxChanges) > 0
+}
+
+type TextDiffKind string
+
+const (
+	TextAdded   TextDiffKind = "added"
+	TextRemoved TextDiffKind = "removed"
+	TextChanged TextDiffKind = "changed"
+)
+
+type TextDiff struct {
+	Kind TextDiffKind `json:"kind"`
+	A    string       `json:"a"`
+	B    string       `json:"b"`
+}
+
+// BoxChange is a page box that differs between the documents.
+type BoxChange struct {
+	Box string   `json:"box"`
+	A   *PageBox `json:"a"`
+	B   *PageBox `json:"b"`
+}
+
+// Compare compares a and b page by page, both as text and visually.
+func (cl *Client) Compare(ctx context.Context, a, b string, opts *CompareOpts) (*CompareResult, error) {
+	if err := cl.require(ctx, CapCompare); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &CompareOpts{}
+	}
+
+	var res CompareResult
+
+	err := cl.runJSONOutput(ctx, "compare", &res, func(outputFile string) []string {
+		args := []string{
+			"--compare",
+			"--format=json",
+			NewOutputFileArg(outputFile).ArgString(),
+		}
+		if opts.Resolution > 0 {
+			args = append(args, newStringArg("--resolution", strconv.Itoa(opts.Resolution)).ArgString())
+		}
+		if opts.ReportPDF != "" {
+			args = append(args, newStringArg("--report", "PDF,PATH="+opts.ReportPDF).ArgString())
+		}
+		return cl.seatArgs(append(args, a, b))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.Identical = true
+	for i := range res.Pages {
+		p := &res.Pages[i]
+		p.Visual = p.PixelDelta > opts.Threshold
+		for _, bc := range p.BoxChanges {
+			if bc.A != nil {
+				bc.A.computeSizes()
+			}
+			if bc.B != nil {
+				bc.B.computeSizes()
+			}
+		}
+		if p.Changed() {
+			res.Identical = false
+		}
+	}
+	res.ReportPath = opts.ReportPDF
+
+	return &res, nil
+}
@@ -0,0 +1,103 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeOutputFile(content string) func(args []string) error {
+	return func(args []string) error {
+		for _, a := range args {
+			if path, ok := strings.CutPrefix(a, "--outputfile="); ok {
+				return os.WriteFile(path, []byte(content), 0o644)
+			}
+		}
+		return nil
+	}
+}
+
+func TestCompare(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\nDuration\t00:02\n",
+		Run: writeOutputFile(`{"pages": [
+  {"page": 1, "pixel_delta": 0.0001},
+  {"page": 2, "pixel_delta": 0.2, "regions": [[10, 10, 50, 50]],
+   "text_diffs": [{"kind": "changed", "a": "55 x 55 mm", "b": "70 x 70 mm"}],
+   "box_changes": [{"box": "TrimBox", "a": {"left": 0, "bottom": 0, "right": 155.906, "top": 155.906},
+     "b": {"left": 0, "bottom": 0, "right": 198.425, "top": 198.425}}]},
+  {"page": 3, "missing_in": "b"}
+]}`),
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.Compare(context.Background(), "a.pdf", "b.pdf", &pdftoolbox.CompareOpts{
+		Threshold: 0.001,
+		ReportPDF: "/work/diff.pdf",
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, res.Pages, 3) {
+		t.FailNow()
+	}
+
+	assert.False(t, res.Identical)
+	assert.Equal(t, "/work/diff.pdf", res.ReportPath)
+	assert.Contains(t, exe.LastCall(), "--report=PDF,PATH=/work/diff.pdf")
+
+	assert.False(t, res.Pages[0].Changed())
+
+	page2 := res.Pages[1]
+	assert.True(t, page2.Visual)
+	assert.True(t, page2.TextChanged())
+	assert.InDelta(t, 55, page2.BoxChanges[0].A.WidthMM, 0.01)
+	assert.InDelta(t, 70, page2.BoxChanges[0].B.WidthMM, 0.01)
+
+	assert.True(t, res.Pages[2].Changed())
+}
+
+func TestCompareIdentical(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\nDuration\t00:01\n",
+		Run:    writeOutputFile(`{"pages": [{"page": 1}, {"page": 2}]}`),
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.Compare(context.Background(), "a.pdf", "b.pdf", nil)
+	assert.NoError(t, err)
+	assert.True(t, res.Identical)
+}
+
+func TestCompareUsesLicenseServer(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\nDuration\t00:01\n",
+		Run:    writeOutputFile(`{"pages": [{"page": 1}]}`),
+	}
+
+	server := "license.example.com:4711"
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, LicenseServer: &server})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.Compare(context.Background(), "a.pdf", "b.pdf", nil)
+	assert.NoError(t, err)
+
+	args := exe.LastCall()
+	assert.Equal(t, "--licenseserver="+server, args[0])
+	assert.Equal(t, []string{"a.pdf", "b.pdf"}, args[len(args)-2:])
+}
@@ -0,0 +1,262 @@
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DistOpts submits profile runs to a pdfToolbox dispatcher, which hands
+// them to a connected satellite instead of processing them locally.
+type DistOpts struct {
+	// Endpoint is the host:port of the dispatcher. Empty uses the
+	// dispatcher configured for pdfToolbox.
+	Endpoint string
+	// Timeout is how long to wait for a satellite to accept the job
+	Timeout time.Duration
+	// NoLocalFallback fails the job instead of processing it locally when
+	// no satellite accepts it in time
+	NoLocalFallback bool
+}
+
+// Args returns the args that submit a run as described by o.
+func (o DistOpts) Args() []Arg {
+	args := []Arg{NewDistArg()}
+	if o.Endpoint != "" {
+		args = append(args, NewEndpointArg(o.Endpoint))
+	}
+	if o.Timeout > 0 {
+		args = append(args, NewDistTimeoutArg(o.Timeout))
+	}
+	if o.NoLocalFallback {
+		args = append(args, NewNoLocalFallbackArg())
+	}
+	return args
+}
+
+func NewDistArg() Arg {
+	return Arg{Arg: "--dist"}
+}
+
+func NewEndpointArg(addr string) Arg {
+	return Arg{Arg: "--endpoint", Value: &addr}
+}
+
+func NewDistTimeoutArg(dur time.Duration) Arg {
+	s := fmt.Sprintf("%.0f", math.Ceil(dur.Seconds()))
+	return Arg{Arg: "--timeout_dispatcher", Value: &s}
+}
+
+func NewNoLocalFallbackArg() Arg {
+	return Arg{Arg: "--nolocal"}
+}
+
+// distArgs are the args that configure dist submission. A run passing any
+// of them keeps its own and gets none from ClientOpts.Dist.
+var distArgs = []string{"--dist", "--endpoint", "--timeout_dispatcher", "--nolocal"}
+
+// hasArg reports whether args contain any of names.
+func hasArg(args []Arg, names ...string) bool {
+	for _, a := range args {
+		if slices.Contains(names, a.Arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// SupervisorOpts configures the dispatcher or satellite processes started by
+// StartDispatcher and StartSatellites.
+type SupervisorOpts struct {
+	// Endpoint is the host:port the dispatcher listens on and satellites
+	// connect to, e.g. "localhost:1200"
+	Endpoint string
+	// Processes is the number of satellites to run, ignored for the
+	// dispatcher. Each satellite takes a licence seat. Defaults to 1.
+	Processes int
+	// RestartDelay is waited before a process that exited is started
+	// again. It doubles with every exit in a row, up to MaxRestartDelay.
+	// Defaults to one second.
+	RestartDelay time.Duration
+	// MaxRestartDelay caps the restart delay. A process that stayed up for
+	// this long starts the next delay at RestartDelay again. Defaults to one
+	// minute.
+	MaxRestartDelay time.Duration
+}
+
+// Supervisor keeps long-running pdfToolbox processes alive, restarting them
+// whenever they exit until Stop is called.
+type Supervisor struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	running  int
+	restarts int
+}
+
+// StartDispatcher runs `pdfToolbox --dispatcher` listening on
+// opts.Endpoint.
+func (cl *Client) StartDispatcher(ctx context.Context, opts SupervisorOpts) (*Supervisor, error) {
+	if opts.Endpoint == "" {
+		return nil, errors.New("pdftoolbox: dispatcher needs an endpoint")
+	}
+
+	return cl.supervise(ctx, 1, opts, "--dispatcher", NewEndpointArg(opts.Endpoint).ArgString()), nil
+}
+
+// StartSatellites runs opts.Processes `pdfToolbox --satellite` processes
+// connected to the dispatcher at opts.Endpoint.
+func (cl *Client) StartSatellites(ctx context.Context, opts SupervisorOpts) (*Supervisor, error) {
+	if opts.Endpoint == "" {
+		return nil, errors.New("pdftoolbox: satellites need a dispatcher endpoint")
+	}
+
+	n := opts.Processes
+	if n <= 0 {
+		n = 1
+	}
+
+	args := cl.seatArgs([]string{"--satellite", NewEndpointArg(opts.Endpoint).ArgString()})
+	return cl.supervise(ctx, n, opts, args...), nil
+}
+
+func (cl *Client) supervise(ctx context.Context, n int, opts SupervisorOpts, args ...string) *Supervisor {
+	backoff := RetryPolicy{InitialBackoff: opts.RestartDelay, MaxBackoff: opts.MaxRestartDelay}
+	if backoff.InitialBackoff <= 0 {
+		backoff.InitialBackoff = time.Second
+	}
+	if backoff.MaxBackoff <= 0 {
+		backoff.MaxBackoff = time.Minute
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Supervisor{cancel: cancel}
+
+	for i := 0; i < n; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.keepAlive(ctx, cl, backoff, args)
+		}()
+	}
+
+	return s
+}
+
+func (s *Supervisor) keepAlive(ctx context.Context, cl *Client, backoff RetryPolicy, args []string) {
+	exits := 0
+	for {
+		s.mu.Lock()
+		s.running++
+		s.mu.Unlock()
+
+		startedAt := time.Now()
+		err := cl.runSupervised(ctx, args)
+
+		s.mu.Lock()
+		s.running--
+		s.mu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(startedAt) >= backoff.MaxBackoff {
+			exits = 0
+		}
+		exits++
+		delay := backoff.Backoff(exits)
+
+		cl.logger.Warn("pdftoolbox process exited, restarting",
+			slog.Any("args", args), slog.Any("error", err), slog.Duration("delay", delay))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		s.mu.Lock()
+		s.restarts++
+		s.mu.Unlock()
+	}
+}
+
+// supervisedOutputTail is how much of a supervised process's output is kept
+// to explain why it exited.
+const supervisedOutputTail = 4096
+
+// runSupervised runs a long-running process. Unlike streamCmd it does not
+// keep the whole output, only its tail for the returned error.
+func (cl *Client) runSupervised(ctx context.Context, args []string) error {
+	cmd := cl.executor.CommandContext(ctx, cl.exePath, args...)
+	cl.logger.Debug("running command", slog.String("cmd", cmd.String()))
+
+	tail := &tailWriter{max: supervisedOutputTail}
+
+	var err error
+	if se, ok := cl.executor.(StreamingExecutor); ok {
+		err = se.StreamOutput(cmd, tail)
+	} else {
+		var out []byte
+		out, err = cl.executor.CombinedOutput(cmd)
+		tail.Write(out)
+	}
+
+	exitCode := cl.executor.ExitCode(cmd)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return &CancelledError{Command: cmd.String(), Err: ctxErr}
+	}
+	if err != nil && exitCode < 0 {
+		return err
+	}
+	return NewParsedError(exitCode, tail.Bytes())
+}
+
+// tailWriter keeps the last max bytes written to it.
+type tailWriter struct {
+	buf []byte
+	max int
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(p) > w.max {
+		p = p[len(p)-w.max:]
+	}
+	if drop := len(w.buf) + len(p) - w.max; drop > 0 {
+		w.buf = append(w.buf[:0], w.buf[drop:]...)
+	}
+	w.buf = append(w.buf, p...)
+	return n, nil
+}
+
+func (w *tailWriter) Bytes() []byte {
+	return w.buf
+}
+
+// Running returns the number of processes currently running.
+func (s *Supervisor) Running() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Restarts returns how often processes have been restarted after exiting.
+func (s *Supervisor) Restarts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.restarts
+}
+
+// Stop kills the supervised processes and waits for them to exit.
+func (s *Supervisor) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
@@ -0,0 +1,178 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunProfileDist(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\nDispatcher\tlocalhost:1200\tJob accepted\nSatellite\tlocalhost:51234\tProcessing\nProgress\t100\nFinished\t/tmp/in.pdf\n",
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		Dist: &pdftoolbox.DistOpts{
+			Endpoint:        "localhost:1200",
+			Timeout:         30 * time.Second,
+			NoLocalFallback: true,
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var events []pdftoolbox.Event
+	out, err := cl.RunProfileStream(context.Background(), "/tmp/profile.kfpx", []string{"/tmp/in.pdf"}, func(ev pdftoolbox.Event) {
+		if ev.Type == pdftoolbox.DispatchEvent {
+			events = append(events, ev)
+		}
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, []string{
+		"--dist", "--endpoint=localhost:1200", "--timeout_dispatcher=30", "--nolocal",
+		"/tmp/profile.kfpx", "/tmp/in.pdf",
+	}, exe.LastCall())
+	assert.Equal(t, "localhost:51234", out.Satellite)
+
+	if assert.Len(t, events, 2) {
+		line := events[0].Line.(pdftoolbox.CmdOutputDispatchLine)
+		assert.Equal(t, "Dispatcher", line.Role)
+		assert.Equal(t, "localhost:1200", line.Address)
+		assert.Equal(t, "Job accepted", line.Message)
+	}
+}
+
+func TestRunProfileDistExplicitArgs(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n"}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		Dist:     &pdftoolbox.DistOpts{Endpoint: "localhost:1200"},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.RunProfile("/tmp/profile.kfpx", []string{"/tmp/in.pdf"}, pdftoolbox.NewDistArg(), pdftoolbox.NewEndpointArg("other:1200"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--dist", "--endpoint=other:1200", "/tmp/profile.kfpx", "/tmp/in.pdf"}, exe.LastCall())
+
+	// Any dist arg means the caller configures dist itself
+	_, err = cl.RunProfile("/tmp/profile.kfpx", []string{"/tmp/in.pdf"}, pdftoolbox.NewNoLocalFallbackArg())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--nolocal", "/tmp/profile.kfpx", "/tmp/in.pdf"}, exe.LastCall())
+}
+
+func TestSupervisorLocalhost(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n", Delay: time.Hour}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	opts := pdftoolbox.SupervisorOpts{Endpoint: "localhost:1200", Processes: 2}
+
+	dispatcher, err := cl.StartDispatcher(context.Background(), opts)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	satellites, err := cl.StartSatellites(context.Background(), opts)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Eventually(t, func() bool {
+		return dispatcher.Running() == 1 && satellites.Running() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	satellites.Stop()
+	dispatcher.Stop()
+
+	assert.Equal(t, 0, satellites.Running())
+	assert.Equal(t, 0, satellites.Restarts())
+	assert.ElementsMatch(t, [][]string{
+		{"--dispatcher", "--endpoint=localhost:1200"},
+		{"--satellite", "--endpoint=localhost:1200"},
+		{"--satellite", "--endpoint=localhost:1200"},
+	}, exe.Calls())
+}
+
+func TestSupervisorRestarts(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Satellite\tlocalhost:1200\tConnection lost\n", ExitCode: 102}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	s, err := cl.StartSatellites(context.Background(), pdftoolbox.SupervisorOpts{
+		Endpoint:     "localhost:1200",
+		RestartDelay: time.Millisecond,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Eventually(t, func() bool {
+		return s.Restarts() >= 3
+	}, time.Second, 5*time.Millisecond)
+	s.Stop()
+
+	_, err = cl.StartSatellites(context.Background(), pdftoolbox.SupervisorOpts{})
+	assert.Error(t, err)
+}
+
+func TestSupervisorBacksOff(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Satellite\tlocalhost:1200\tConnection lost\n", ExitCode: 102}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	s, err := cl.StartSatellites(context.Background(), pdftoolbox.SupervisorOpts{
+		Endpoint:     "localhost:1200",
+		RestartDelay: 20 * time.Millisecond,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// Waits of 20, 40 and 80ms allow at most 3 restarts in 200ms, a fixed
+	// delay would allow 10
+	time.Sleep(200 * time.Millisecond)
+	s.Stop()
+	assert.GreaterOrEqual(t, s.Restarts(), 1)
+	assert.LessOrEqual(t, s.Restarts(), 3)
+}
+
+func TestTailWriter(t *testing.T) {
+	w := pdftoolbox.NewTailWriter(8)
+	w.Write([]byte("abc"))
+	w.Write([]byte("defgh"))
+	assert.Equal(t, "abcdefgh", string(w.Bytes()))
+
+	n, err := w.Write([]byte("ijk"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "defghijk", string(w.Bytes()))
+
+	w.Write([]byte(strings.Repeat("x", 20) + "12345678"))
+	assert.Equal(t, "12345678", string(w.Bytes()))
+}
@@ -1,25 +1,214 @@
 package pdftoolbox
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ReasonCode is the code of an `Error	<code>	<message>` line.
 type ReasonCode uint16
+
+// ReturnCode is the exit code of the pdfToolbox process.
 type ReturnCode uint8
+
+// ErrorCode is the exit code of a failed run, from 100 up.
 type ErrorCode uint8
 
+// Process exit codes. Codes below 100 mean the run completed and report the
+// most severe hit; codes from 100 up mean it failed.
+const (
+	ReturnNoHits        ReturnCode = 0
+	ReturnInfos         ReturnCode = 1
+	ReturnWarnings      ReturnCode = 2
+	ReturnErrors        ReturnCode = 3
+	ReturnNotSerialized ReturnCode = 100
+	ReturnNotPermitted  ReturnCode = 101
+	ReturnFailed        ReturnCode = 102
+	ReturnProfileFailed ReturnCode = 103
+	ReturnEncrypted     ReturnCode = 104
+	ReturnDamaged       ReturnCode = 105
+	ReturnTimeout       ReturnCode = 106
+	ReturnInternal      ReturnCode = 107
+)
+
+// CodeNotSerialized is the exit code of a run without a valid serialization
+// or with an expired keycode.
+const CodeNotSerialized = ErrorCode(ReturnNotSerialized)
+
+// Codes reported on Error lines.
+const (
+	CodeInvalidArgument    ReasonCode = 1001
+	CodeFileNotFound       ReasonCode = 1002
+	CodeWriteFailed        ReasonCode = 1003
+	CodeProfileInvalid     ReasonCode = 1004
+	CodeEncrypted          ReasonCode = 1005
+	CodeDamaged            ReasonCode = 1006
+	CodeTimeout            ReasonCode = 1007
+	CodeNotActivated       ReasonCode = 1008
+	CodeLicenseServer      ReasonCode = 1009
+	CodeFileLocked         ReasonCode = 1010
+	CodeNotPermitted       ReasonCode = 1011
+	CodeInsufficientMemory ReasonCode = 1012
+	CodeInternal           ReasonCode = 1013
+)
+
+type ErrorClass int
+
+const (
+	ClassUnknown ErrorClass = iota
+	// ClassRetryable failures may succeed when the same job is run again.
+	ClassRetryable
+	// ClassConfig failures need the installation, licence or profile fixed.
+	ClassConfig
+	// ClassInput failures are caused by the input file or the job's
+	// variables.
+	ClassInput
+)
+
+type codeInfo struct {
+	message string
+	class   ErrorClass
+}
+
+var returnCatalog = map[ReturnCode]codeInfo{
+	ReturnNoHits:        {"Success, no hits", ClassUnknown},
+	ReturnInfos:         {"Success, hits of severity info", ClassUnknown},
+	ReturnWarnings:      {"Success, hits of severity warning", ClassUnknown},
+	ReturnErrors:        {"Success, hits of severity error", ClassUnknown},
+	ReturnNotSerialized: {"Not serialized (no valid serialization found or keycode expired)", ClassConfig},
+	ReturnNotPermitted:  {"Function not permitted by the serialization", ClassConfig},
+	ReturnFailed:        {"Processing failed, see the Error line", ClassUnknown},
+	ReturnProfileFailed: {"Profile could not be loaded", ClassConfig},
+	ReturnEncrypted:     {"PDF is encrypted", ClassInput},
+	ReturnDamaged:       {"PDF is damaged", ClassInput},
+	ReturnTimeout:       {"Processing stopped by --timeout", ClassRetryable},
+	ReturnInternal:      {"Internal error", ClassUnknown},
+}
+
+var reasonCatalog = map[ReasonCode]codeInfo{
+	CodeInvalidArgument:    {"Invalid command line argument", ClassConfig},
+	CodeFileNotFound:       {"File or folder not found", ClassInput},
+	CodeWriteFailed:        {"Could not write file", ClassRetryable},
+	CodeProfileInvalid:     {"Profile is invalid or could not be loaded", ClassConfig},
+	CodeEncrypted:          {"PDF is encrypted", ClassInput},
+	CodeDamaged:            {"PDF is damaged", ClassInput},
+	CodeTimeout:            {"Processing timed out", ClassRetryable},
+	CodeNotActivated:       {"Not activated (no license)", ClassConfig},
+	CodeLicenseServer:      {"License server not reachable", ClassRetryable},
+	CodeFileLocked:         {"File is locked by another process", ClassRetryable},
+	CodeNotPermitted:       {"Function not permitted by the license", ClassConfig},
+	CodeInsufficientMemory: {"Insufficient memory", ClassRetryable},
+	CodeInternal:           {"Internal error", ClassUnknown},
+}
+
+// returnReasons maps failing exit codes to the Error line they stand for,
+// for runs that fail without printing one. Exit code 100 has no entry: an
+// expired keycode is not the same failure as a missing licence.
+var returnReasons = map[ReturnCode]ReasonCode{
+	ReturnNotPermitted:  CodeNotPermitted,
+	ReturnProfileFailed: CodeProfileInvalid,
+	ReturnEncrypted:     CodeEncrypted,
+	ReturnDamaged:       CodeDamaged,
+	ReturnTimeout:       CodeTimeout,
+	ReturnInternal:      CodeInternal,
+}
+
+// Success reports whether the run completed, regardless of hits.
+func (c ReturnCode) Success() bool {
+	return c < ReturnNotSerialized
+}
+
+func (c ReturnCode) String() string {
+	if info, ok := returnCatalog[c]; ok {
+		return info.message
+	}
+	return fmt.Sprintf("return code %d", uint8(c))
+}
+
+// Error is a pdfToolbox failure. Sentinels set either Code, matching the
+// exit code, or Reason, matching the Error line.
 type Error struct {
 	Code    ErrorCode
+	Reason  ReasonCode
 	Message string
 }
 
+func (e Error) Error() string {
+	if e.Reason != 0 {
+		return fmt.Sprintf("pdftoolbox: %s (%d)", e.Message, e.Reason)
+	}
+	return fmt.Sprintf("pdftoolbox: %s (exit code %d)", e.Message, e.Code)
+}
+
+// Class returns how the error should be handled.
+func (e Error) Class() ErrorClass {
+	if e.Reason != 0 {
+		return reasonCatalog[e.Reason].class
+	}
+	return returnCatalog[ReturnCode(e.Code)].class
+}
+
 func newError(code ErrorCode) Error {
 	e := Error{
 		Code:    code,
-		Message: errors[code],
+		Message: returnCatalog[ReturnCode(code)].message,
 	}
 
 	return e
 }
 
-const CodeNotSerialized ErrorCode = 100
+func newReasonError(reason ReasonCode) Error {
+	return Error{
+		Reason:  reason,
+		Message: reasonCatalog[reason].message,
+	}
+}
+
+// Sentinel errors matched by errors.Is against a *ParsedError.
+var (
+	ErrNotSerialized      = newError(CodeNotSerialized)
+	ErrInvalidArgument    = newReasonError(CodeInvalidArgument)
+	ErrFileNotFound       = newReasonError(CodeFileNotFound)
+	ErrWriteFailed        = newReasonError(CodeWriteFailed)
+	ErrProfileInvalid     = newReasonError(CodeProfileInvalid)
+	ErrEncrypted          = newReasonError(CodeEncrypted)
+	ErrDamaged            = newReasonError(CodeDamaged)
+	ErrTimeout            = newReasonError(CodeTimeout)
+	ErrNotActivated       = newReasonError(CodeNotActivated)
+	ErrLicenseServer      = newReasonError(CodeLicenseServer)
+	ErrFileLocked         = newReasonError(CodeFileLocked)
+	ErrNotPermitted       = newReasonError(CodeNotPermitted)
+	ErrInsufficientMemory = newReasonError(CodeInsufficientMemory)
+	ErrInternal           = newReasonError(CodeInternal)
+)
+
+// Classify returns the class of a pdfToolbox failure, or ClassUnknown for
+// errors that did not come from pdfToolbox.
+func Classify(err error) ErrorClass {
+	var pe *ParsedError
+	var e Error
+	var ve *VariablesError
+
+	switch {
+	case errors.As(err, &pe):
+		return pe.class()
+	case errors.As(err, &e):
+		return e.Class()
+	case errors.As(err, &ve):
+		return ClassInput
+	}
+
+	return ClassUnknown
+}
+
+func IsRetryable(err error) bool {
+	return Classify(err) == ClassRetryable
+}
+
+func IsConfigError(err error) bool {
+	return Classify(err) == ClassConfig
+}
 
-var errors = map[ErrorCode]string{
-	CodeNotSerialized: "Not serialized (no valid serialization found or keycode expired)",
+func IsInputError(err error) bool {
+	return Classify(err) == ClassInput
 }
@@ -0,0 +1,81 @@
+package pdftoolbox_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsedErrorIs(t *testing.T) {
+	pe := pdftoolbox.NewParsedError(102, []byte("ProcessID\t34562\nError\t1002\tCould not open file /opt/impose/profilesxxx: File or folder not found"))
+
+	assert.True(t, errors.Is(pe, pdftoolbox.ErrFileNotFound))
+	assert.False(t, errors.Is(pe, pdftoolbox.ErrNotActivated))
+	assert.True(t, errors.Is(fmt.Errorf("run: %w", pe), pdftoolbox.ErrFileNotFound))
+	assert.True(t, pdftoolbox.IsInputError(pe))
+	assert.False(t, pdftoolbox.IsRetryable(pe))
+	rc, ok := pe.ReturnCode()
+	assert.True(t, ok)
+	assert.Equal(t, pdftoolbox.ReturnFailed, rc)
+}
+
+func TestParsedErrorFromReturnCode(t *testing.T) {
+	pe := pdftoolbox.NewParsedError(int(pdftoolbox.ReturnNotSerialized), []byte("ProcessID\t34562\n"))
+
+	assert.Equal(t, pdftoolbox.CodeNotSerialized, pe.ErrorCode())
+	assert.Equal(t, pdftoolbox.ReasonCode(0), pe.Reason())
+	assert.True(t, errors.Is(pe, pdftoolbox.ErrNotSerialized))
+	assert.False(t, errors.Is(pe, pdftoolbox.ErrNotActivated))
+	assert.True(t, pdftoolbox.IsConfigError(pe))
+	assert.Contains(t, pe.Error(), "exit code 100")
+
+	// Not activated: both the exit code and the Error line match
+	pe = pdftoolbox.NewParsedError(100, []byte("ProcessID\t34562\nError\t1008\tNot activated (no license found)\n"))
+	assert.True(t, errors.Is(pe, pdftoolbox.ErrNotSerialized))
+	assert.True(t, errors.Is(pe, pdftoolbox.ErrNotActivated))
+
+	pe = pdftoolbox.NewParsedError(int(pdftoolbox.ReturnTimeout), nil)
+	assert.Equal(t, pdftoolbox.CodeTimeout, pe.Reason())
+	assert.True(t, errors.Is(pe, pdftoolbox.ErrTimeout))
+	assert.True(t, pdftoolbox.IsRetryable(pe))
+
+	pe = pdftoolbox.NewParsedError(int(pdftoolbox.ReturnProfileFailed), nil)
+	assert.True(t, errors.Is(pe, pdftoolbox.ErrProfileInvalid))
+	assert.True(t, pdftoolbox.IsConfigError(pe))
+
+	// Killed by a signal
+	pe = pdftoolbox.NewParsedError(-1, nil)
+	_, ok := pe.ReturnCode()
+	assert.False(t, ok)
+	assert.Equal(t, pdftoolbox.ErrorCode(0), pe.ErrorCode())
+	assert.Equal(t, pdftoolbox.ReasonCode(0), pe.Reason())
+	assert.Equal(t, "pdftoolbox: exit code -1", pe.Error())
+	assert.Equal(t, pdftoolbox.ClassUnknown, pdftoolbox.Classify(pe))
+}
+
+func TestErrorLineTakesPrecedence(t *testing.T) {
+	pe := pdftoolbox.NewParsedError(102, []byte("Error\t1009\tLicense server not reachable\n"))
+
+	assert.True(t, errors.Is(pe, pdftoolbox.ErrLicenseServer))
+	assert.False(t, errors.Is(pe, pdftoolbox.ErrNotSerialized))
+	assert.True(t, pdftoolbox.IsRetryable(pe))
+	assert.Equal(t, "pdftoolbox: License server not reachable (1009)", pdftoolbox.ErrLicenseServer.Error())
+	assert.Equal(t, "pdftoolbox: Not serialized (no valid serialization found or keycode expired) (exit code 100)", pdftoolbox.ErrNotSerialized.Error())
+}
+
+func TestClassifyJoined(t *testing.T) {
+	pe := pdftoolbox.NewParsedError(102, []byte("Error\t1002\tFile or folder not found\n"))
+
+	assert.True(t, pdftoolbox.IsInputError(errors.Join(errors.New("cleanup failed"), pe)))
+	assert.True(t, pdftoolbox.IsInputError(fmt.Errorf("%w: %w", errors.New("run"), pe)))
+}
+
+func TestReturnCodeSuccess(t *testing.T) {
+	assert.True(t, pdftoolbox.ReturnErrors.Success())
+	assert.False(t, pdftoolbox.ReturnNotSerialized.Success())
+	assert.Equal(t, "return code 250", pdftoolbox.ReturnCode(250).String())
+	assert.Equal(t, pdftoolbox.ClassUnknown, pdftoolbox.Classify(errors.New("other")))
+}
@@ -0,0 +1,142 @@
+package pdftoolbox
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+)
+
+type EventType string
+
+const (
+	ProgressEvent EventType = "progress"
+	StepEvent     EventType = "step"
+	HitEvent      EventType = "hit"
+	FixEvent      EventType = "fix"
+	VariableEvent EventType = "variable"
+	OutputEvent   EventType = "output"
+	SummaryEvent  EventType = "summary"
+	FinishedEvent EventType = "finished"
+	DispatchEvent EventType = "dispatch"
+	// RetryEvent is emitted before a RetryPolicy runs the profile again.
+	// Events of the failed attempt have already been delivered; consumers
+	// should discard the steps, hits and progress they collected for it.
+	RetryEvent EventType = "retry"
+)
+
+// Event is emitted for each progress-related line while a profile runs.
+type Event struct {
+	Type EventType
+	// Step is the name of the step the line belongs to, empty before the
+	// first Step line.
+	Step string
+	// Progress is the percentage of a Progress line.
+	Progress int
+	// Line is the parsed line, nil for retry events.
+	Line CmdOutputLine
+	// Attempt is the 1-based run the event belongs to.
+	Attempt int
+	// Err is the failure of the previous attempt, for retry events.
+	Err error
+}
+
+type EventHandler func(Event)
+
+// StreamingExecutor is implemented by executors that can deliver output while
+// the process is still running. Executors that only implement
+// PDFToolboxExecutor still work; their events are delivered after exit.
+type StreamingExecutor interface {
+	StreamOutput(cmd *exec.Cmd, w io.Writer) error
+}
+
+func (e Executor) StreamOutput(cmd *exec.Cmd, w io.Writer) error {
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
+
+func newEvent(line CmdOutputLine, step string) (Event, bool) {
+	ev := Event{Step: step, Line: line}
+
+	switch l := line.(type) {
+	case CmdOutputProgressLine:
+		ev.Type = ProgressEvent
+		ev.Progress = l.Percent
+	case CmdOutputHitLine:
+		ev.Type = HitEvent
+	case CmdOutputFixLine:
+		ev.Type = FixEvent
+	case CmdOutputVariableLine:
+		ev.Type = VariableEvent
+	case CmdOutputSummaryLine:
+		ev.Type = SummaryEvent
+	case CmdOutputFinishedLine:
+		ev.Type = FinishedEvent
+	case CmdOutputDispatchLine:
+		ev.Type = DispatchEvent
+	case CmdOutputIdentityLine:
+		switch field(l.Parts, 0) {
+		case "Step":
+			ev.Type = StepEvent
+		case "Output":
+			ev.Type = OutputEvent
+		default:
+			return Event{}, false
+		}
+	default:
+		return Event{}, false
+	}
+
+	return ev, true
+}
+
+// lineWriter buffers everything written to it and feeds complete lines to an
+// OutputParser, emitting events as it goes.
+type lineWriter struct {
+	raw     bytes.Buffer
+	pending []byte
+	parser  *OutputParser
+	onEvent EventHandler
+}
+
+func newLineWriter(onEvent EventHandler) *lineWriter {
+	return &lineWriter{
+		parser:  NewOutputParser(),
+		onEvent: onEvent,
+	}
+}
+
+func (w *lineWriter) Write(b []byte) (int, error) {
+	w.raw.Write(b)
+	w.pending = append(w.pending, b...)
+
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.parseLine(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+	}
+
+	return len(b), nil
+}
+
+// Flush parses a trailing line that was not terminated by a newline.
+func (w *lineWriter) Flush() {
+	if len(w.pending) > 0 {
+		w.parseLine(string(w.pending))
+		w.pending = nil
+	}
+}
+
+func (w *lineWriter) parseLine(s string) {
+	line := w.parser.ParseLine(s)
+	if line == nil || w.onEvent == nil {
+		return
+	}
+
+	if ev, ok := newEvent(line, w.parser.StepName()); ok {
+		w.onEvent(ev)
+	}
+}
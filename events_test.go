@@ -0,0 +1,68 @@
+package pdftoolbox
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineWriterMatchesParseOutput(t *testing.T) {
+	output := "ProcessID\t1\nStep\tFixup\tA\nProgress\t40\t%\nOutput\t/tmp/a.pdf\nStep\tCreate PDF copy\nOutput\t/tmp/b.pdf\nDuration\t00:03"
+
+	var events []Event
+	w := newLineWriter(func(ev Event) {
+		events = append(events, ev)
+	})
+
+	// Split writes in the middle of lines to mimic pipe reads
+	for _, chunk := range []string{output[:5], output[5:30], output[30:]} {
+		w.Write([]byte(chunk))
+	}
+	w.Flush()
+
+	streamed := w.parser.Result()
+	streamed.Raw = w.raw.String()
+
+	parsed, err := ParseOutput(output)
+	assert.NoError(t, err)
+	assert.Equal(t, parsed, streamed)
+
+	if !assert.Len(t, events, 5) {
+		t.FailNow()
+	}
+	assert.Equal(t, StepEvent, events[0].Type)
+	assert.Equal(t, ProgressEvent, events[1].Type)
+	assert.Equal(t, 40, events[1].Progress)
+	assert.Equal(t, "Fixup", events[1].Step)
+	assert.Equal(t, OutputEvent, events[4].Type)
+	assert.Equal(t, "Create PDF copy", events[4].Step)
+}
+
+func TestRunProfileStream(t *testing.T) {
+	_, err := os.Stat("/bin/sh")
+	if os.IsNotExist(err) {
+		t.Skip()
+	}
+
+	cl, err := New("/bin/sh", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var progress []int
+	res, err := cl.RunProfileStream(context.Background(), "-c", []string{`printf 'Progress\t10\t%%\nProgress\t100\t%%\nFinished\tin.pdf\n'`}, func(ev Event) {
+		if ev.Type == ProgressEvent {
+			progress = append(progress, ev.Progress)
+		}
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, []int{10, 100}, progress)
+	assert.Len(t, res.Lines, 3)
+	assert.Equal(t, 3, strings.Count(res.Raw, "\n"))
+}
@@ -0,0 +1,7 @@
+package pdftoolbox
+
+// Unexported helpers exposed to the external tests.
+
+func NewTailWriter(max int) *tailWriter {
+	return &tailWriter{max: max}
+}
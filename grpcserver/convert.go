@@ -0,0 +1,209 @@
+package grpcserver
+
+import (
+	"fmt"
+	"io/fs"
+	"mime"
+	"path/filepath"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxpb"
+)
+
+var eventTypes = map[pdftoolbox.EventType]pdftoolboxpb.EventType{
+	pdftoolbox.ProgressEvent: pdftoolboxpb.EventType_EVENT_TYPE_PROGRESS,
+	pdftoolbox.StepEvent:     pdftoolboxpb.EventType_EVENT_TYPE_STEP,
+	pdftoolbox.HitEvent:      pdftoolboxpb.EventType_EVENT_TYPE_HIT,
+	pdftoolbox.FixEvent:      pdftoolboxpb.EventType_EVENT_TYPE_FIX,
+	pdftoolbox.VariableEvent: pdftoolboxpb.EventType_EVENT_TYPE_VARIABLE,
+	pdftoolbox.OutputEvent:   pdftoolboxpb.EventType_EVENT_TYPE_OUTPUT,
+	pdftoolbox.SummaryEvent:  pdftoolboxpb.EventType_EVENT_TYPE_SUMMARY,
+	pdftoolbox.FinishedEvent: pdftoolboxpb.EventType_EVENT_TYPE_FINISHED,
+	pdftoolbox.DispatchEvent: pdftoolboxpb.EventType_EVENT_TYPE_DISPATCH,
+	pdftoolbox.RetryEvent:    pdftoolboxpb.EventType_EVENT_TYPE_RETRY,
+}
+
+func toEvent(ev pdftoolbox.Event) *pdftoolboxpb.Event {
+	pe := &pdftoolboxpb.Event{
+		Type:     eventTypes[ev.Type],
+		Step:     ev.Step,
+		Progress: int32(ev.Progress),
+		Attempt:  int32(ev.Attempt),
+	}
+	if ev.Line != nil {
+		pe.Line = ev.Line.String()
+	}
+	if ev.Err != nil {
+		pe.Error = ev.Err.Error()
+	}
+
+	switch l := ev.Line.(type) {
+	case pdftoolbox.CmdOutputHitLine:
+		pe.Hit = toHit(l)
+	case pdftoolbox.CmdOutputFixLine:
+		pe.Fix = l.Name
+	case pdftoolbox.CmdOutputVariableLine:
+		pe.Variable = &pdftoolboxpb.Variable{Key: l.Name, Value: l.Value}
+	}
+
+	return pe
+}
+
+func toHit(l pdftoolbox.CmdOutputHitLine) *pdftoolboxpb.Hit {
+	return &pdftoolboxpb.Hit{Severity: l.Severity, Message: l.Message}
+}
+
+func toHits(lines []pdftoolbox.CmdOutputHitLine) []*pdftoolboxpb.Hit {
+	hits := make([]*pdftoolboxpb.Hit, 0, len(lines))
+	for _, l := range lines {
+		hits = append(hits, toHit(l))
+	}
+	return hits
+}
+
+func toFixes(lines []pdftoolbox.CmdOutputFixLine) []string {
+	fixes := make([]string, 0, len(lines))
+	for _, l := range lines {
+		fixes = append(fixes, l.Name)
+	}
+	return fixes
+}
+
+func toSummary(s pdftoolbox.Summary) *pdftoolboxpb.Summary {
+	return &pdftoolboxpb.Summary{
+		Corrections: int32(s.Corrections),
+		Errors:      int32(s.Errors),
+		Warnings:    int32(s.Warnings),
+		Infos:       int32(s.Infos),
+		Verdict:     string(s.Verdict()),
+	}
+}
+
+func toRunProfileResponse(jobID string, out pdftoolbox.CmdOutput, artifacts []*pdftoolboxpb.Artifact) *pdftoolboxpb.RunProfileResponse {
+	resp := &pdftoolboxpb.RunProfileResponse{
+		JobId:      jobID,
+		ExitCode:   int32(out.ExitCode),
+		Summary:    toSummary(out.Summary),
+		Hits:       toHits(out.Hits),
+		Fixes:      toFixes(out.Fixes),
+		Artifacts:  artifacts,
+		DurationMs: out.Duration.Milliseconds(),
+		Satellite:  out.Satellite,
+		RawOutput:  out.Raw,
+	}
+
+	for _, step := range out.Steps {
+		resp.Steps = append(resp.Steps, &pdftoolboxpb.Step{
+			Name:        step.Name,
+			Summary:     toSummary(step.Summary),
+			Hits:        toHits(step.Hits),
+			Fixes:       toFixes(step.Fixes),
+			OutputFiles: step.OutputFilePaths,
+		})
+	}
+
+	return resp
+}
+
+// listArtifacts lists the files a job wrote to its output folder, whether
+// or not pdfToolbox printed an Output line for them.
+func listArtifacts(dir string) []*pdftoolboxpb.Artifact {
+	var out []*pdftoolboxpb.Artifact
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		name, _ := filepath.Rel(dir, path)
+		out = append(out, &pdftoolboxpb.Artifact{
+			Name:     filepath.ToSlash(name),
+			Size:     fi.Size(),
+			MimeType: mime.TypeByExtension(filepath.Ext(name)),
+		})
+		return nil
+	})
+	return out
+}
+
+func toEnumerateProfilesResponse(resp *pdftoolbox.EnumerateProfilesResponse, folder string) *pdftoolboxpb.EnumerateProfilesResponse {
+	out := &pdftoolboxpb.EnumerateProfilesResponse{}
+
+	for _, p := range resp.Profiles {
+		// Clients refer to profiles relative to the profile folder
+		path := p.Path
+		if rel, err := filepath.Rel(folder, p.Path); err == nil && filepath.IsLocal(rel) {
+			path = filepath.ToSlash(rel)
+		}
+
+		profile := &pdftoolboxpb.Profile{Name: p.Name, Path: path, Comment: p.Comment}
+		for _, v := range p.Variables {
+			value := ""
+			if v.Value != nil {
+				value = fmt.Sprint(v.Value)
+			}
+			profile.Variables = append(profile.Variables, &pdftoolboxpb.Variable{
+				Key:   v.Key,
+				Label: v.Label,
+				Type:  v.Type,
+				Value: value,
+			})
+		}
+
+		out.Profiles = append(out.Profiles, profile)
+	}
+
+	return out
+}
+
+func toPageBox(b *pdftoolbox.PageBox) *pdftoolboxpb.PageBox {
+	if b == nil {
+		return nil
+	}
+	return &pdftoolboxpb.PageBox{
+		Left:     b.Left,
+		Bottom:   b.Bottom,
+		Right:    b.Right,
+		Top:      b.Top,
+		WidthMm:  b.WidthMM,
+		HeightMm: b.HeightMM,
+	}
+}
+
+func toQuickCheckResponse(resp *pdftoolbox.QuickCheckResponse) *pdftoolboxpb.QuickCheckResponse {
+	doc := resp.Document
+	out := &pdftoolboxpb.QuickCheckResponse{
+		PdfVersion:  doc.PDFVersion,
+		Title:       doc.Title,
+		Creator:     doc.Creator,
+		Producer:    doc.Producer,
+		PageCount:   int32(doc.PageCount),
+		FileSize:    doc.FileSize,
+		Encrypted:   doc.Encrypted,
+		Standards:   doc.Standards,
+		ColorSpaces: resp.ColorSpaces,
+	}
+	if resp.OutputIntent != nil {
+		out.OutputConditionIdentifier = resp.OutputIntent.OutputConditionIdentifier
+	}
+
+	for _, p := range resp.Pages {
+		out.Pages = append(out.Pages, &pdftoolboxpb.Page{
+			Number:   int32(p.Number),
+			Rotation: int32(p.Rotation),
+			MediaBox: toPageBox(p.MediaBox),
+			CropBox:  toPageBox(p.CropBox),
+			TrimBox:  toPageBox(p.TrimBox),
+			BleedBox: toPageBox(p.BleedBox),
+			ArtBox:   toPageBox(p.ArtBox),
+		})
+	}
+	for _, f := range resp.Fonts {
+		out.Fonts = append(out.Fonts, &pdftoolboxpb.Font{Name: f.Name, Type: f.Type, Embedded: f.Embedded, Subset: f.Subset})
+	}
+
+	return out
+}
@@ -0,0 +1,87 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errInvalid  = errors.New("invalid argument")
+	errNotFound = errors.New("not found")
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to failures
+// reported by pdfToolbox. Its metadata holds the pdfToolbox error "code",
+// the "exitCode" and, when known, the "class".
+const ErrorDomain = "pdftoolbox"
+
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var ce *pdftoolbox.CancelledError
+	var pe *pdftoolbox.ParsedError
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, errInvalid):
+		code = codes.InvalidArgument
+	case errors.Is(err, errNotFound):
+		code = codes.NotFound
+	case errors.Is(err, pdftoolbox.ErrUnsupported):
+		code = codes.Unimplemented
+	case errors.Is(err, pdftoolbox.ErrPoolClosed):
+		code = codes.Unavailable
+	case errors.As(err, &ce):
+		code = codes.Canceled
+		if errors.Is(err, context.DeadlineExceeded) {
+			code = codes.DeadlineExceeded
+		}
+	default:
+		switch pdftoolbox.Classify(err) {
+		case pdftoolbox.ClassInput:
+			code = codes.InvalidArgument
+		case pdftoolbox.ClassConfig:
+			code = codes.FailedPrecondition
+		case pdftoolbox.ClassRetryable:
+			code = codes.Unavailable
+		}
+	}
+
+	st := status.New(code, err.Error())
+	if !errors.As(err, &pe) {
+		return st.Err()
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason: "PDFTOOLBOX_ERROR",
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			"code":     strconv.Itoa(int(pe.Reason())),
+			"exitCode": strconv.Itoa(pe.ProcessExitCode),
+		},
+	}
+	switch pdftoolbox.Classify(err) {
+	case pdftoolbox.ClassRetryable:
+		info.Metadata["class"] = "retryable"
+	case pdftoolbox.ClassConfig:
+		info.Metadata["class"] = "config"
+	case pdftoolbox.ClassInput:
+		info.Metadata["class"] = "input"
+	}
+
+	if withDetails, err := st.WithDetails(info); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
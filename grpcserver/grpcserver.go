@@ -0,0 +1,418 @@
+// Package grpcserver implements the PDFToolbox gRPC service defined in
+// proto/pdftoolbox/v1/pdftoolbox.proto on top of a pdftoolbox.Client.
+package grpcserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxpb"
+	"google.golang.org/grpc"
+)
+
+type Opts struct {
+	// ProfileFolder holds the profiles jobs may run. Profiles are referred to
+	// by their path relative to it.
+	ProfileFolder string
+	// WorkDir holds uploads and outputs. Defaults to a folder in os.TempDir.
+	WorkDir string
+	// Pool runs the jobs. Pass the pool of an HTTP server to share licence
+	// seats with it; otherwise a pool of Concurrency workers is created.
+	Pool        *pdftoolbox.Pool
+	Concurrency int
+	// Retention is how long uploads and job outputs are kept if they are not
+	// released. Defaults to one hour.
+	Retention time.Duration
+	Logger    *slog.Logger
+}
+
+type Service struct {
+	pdftoolboxpb.UnimplementedPDFToolboxServer
+
+	client   *pdftoolbox.Client
+	pool     *pdftoolbox.Pool
+	ownsPool bool
+	opts     Opts
+
+	mu    sync.Mutex
+	files map[string]*entry
+	jobs  map[string]*entry
+}
+
+// entry is an uploaded file or the folder of a job.
+type entry struct {
+	path    string
+	created time.Time
+}
+
+func New(client *pdftoolbox.Client, opts *Opts) (*Service, error) {
+	s := &Service{
+		client: client,
+		opts: Opts{
+			WorkDir:     filepath.Join(os.TempDir(), "pdftoolbox-grpc"),
+			Concurrency: 1,
+			Retention:   time.Hour,
+			Logger:      slog.Default(),
+		},
+		files: map[string]*entry{},
+		jobs:  map[string]*entry{},
+	}
+
+	if opts != nil {
+		s.opts.ProfileFolder = opts.ProfileFolder
+		s.opts.Pool = opts.Pool
+		if opts.WorkDir != "" {
+			s.opts.WorkDir = opts.WorkDir
+		}
+		if opts.Concurrency > 0 {
+			s.opts.Concurrency = opts.Concurrency
+		}
+		if opts.Retention > 0 {
+			s.opts.Retention = opts.Retention
+		}
+		if opts.Logger != nil {
+			s.opts.Logger = opts.Logger
+		}
+	}
+
+	if s.opts.ProfileFolder == "" {
+		return nil, errors.New("grpcserver: a profile folder is required")
+	}
+	for _, dir := range []string{"uploads", "jobs"} {
+		if err := os.MkdirAll(filepath.Join(s.opts.WorkDir, dir), 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	s.pool = s.opts.Pool
+	if s.pool == nil {
+		s.pool = pdftoolbox.NewPool(client, &pdftoolbox.PoolOpts{Concurrency: s.opts.Concurrency})
+		s.ownsPool = true
+	}
+
+	return s, nil
+}
+
+// Register registers the service with gs.
+func (s *Service) Register(gs *grpc.Server) {
+	pdftoolboxpb.RegisterPDFToolboxServer(gs, s)
+}
+
+// Close shuts down the pool if the service created it and removes all
+// uploads and outputs.
+func (s *Service) Close(ctx context.Context) error {
+	var err error
+	if s.ownsPool {
+		err = s.pool.Shutdown(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range []map[string]*entry{s.files, s.jobs} {
+		for id, e := range m {
+			os.RemoveAll(filepath.Dir(e.path))
+			delete(m, id)
+		}
+	}
+
+	return err
+}
+
+func (s *Service) Upload(stream grpc.ClientStreamingServer[pdftoolboxpb.UploadRequest, pdftoolboxpb.UploadResponse]) error {
+	s.prune()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return toStatus(err)
+	}
+	name := filepath.Base(filepath.Clean("/" + first.GetName()))
+	if name == "/" || name == "." {
+		return toStatus(fmt.Errorf("%w: the first message must carry the file name", errInvalid))
+	}
+
+	id := newID()
+	dir := filepath.Join(s.opts.WorkDir, "uploads", id)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return toStatus(err)
+	}
+
+	p := filepath.Join(dir, name)
+	size, err := receiveFile(stream, p)
+	if err != nil {
+		os.RemoveAll(dir)
+		return toStatus(err)
+	}
+
+	s.mu.Lock()
+	s.files[id] = &entry{path: p, created: time.Now()}
+	s.mu.Unlock()
+
+	return stream.SendAndClose(&pdftoolboxpb.UploadResponse{FileId: id, Size: size})
+}
+
+func receiveFile(stream grpc.ClientStreamingServer[pdftoolboxpb.UploadRequest, pdftoolboxpb.UploadResponse], p string) (int64, error) {
+	f, err := os.Create(p)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var size int64
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return size, f.Close()
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := f.Write(req.GetChunk())
+		if err != nil {
+			return 0, err
+		}
+		size += int64(n)
+	}
+}
+
+func (s *Service) RunProfile(ctx context.Context, req *pdftoolboxpb.RunProfileRequest) (*pdftoolboxpb.RunProfileResponse, error) {
+	resp, err := s.run(ctx, req, nil)
+	return resp, toStatus(err)
+}
+
+func (s *Service) RunProfileStream(req *pdftoolboxpb.RunProfileRequest, stream grpc.ServerStreamingServer[pdftoolboxpb.RunProfileUpdate]) error {
+	// A client that can no longer be sent to does not need the job either
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// Events are sent from the pool worker while this goroutine waits for the
+	// job, so sends never overlap.
+	var sendErr error
+	onEvent := func(ev pdftoolbox.Event) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&pdftoolboxpb.RunProfileUpdate{
+			Update: &pdftoolboxpb.RunProfileUpdate_Event{Event: toEvent(ev)},
+		})
+		if sendErr != nil {
+			cancel()
+		}
+	}
+
+	resp, err := s.run(ctx, req, onEvent)
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return stream.Send(&pdftoolboxpb.RunProfileUpdate{
+		Update: &pdftoolboxpb.RunProfileUpdate_Result{Result: resp},
+	})
+}
+
+func (s *Service) run(ctx context.Context, req *pdftoolboxpb.RunProfileRequest, onEvent pdftoolbox.EventHandler) (*pdftoolboxpb.RunProfileResponse, error) {
+	if req.GetProfile() == "" || !filepath.IsLocal(req.GetProfile()) {
+		return nil, fmt.Errorf("%w: invalid profile %q", errInvalid, req.GetProfile())
+	}
+	if len(req.GetFileIds()) == 0 {
+		return nil, fmt.Errorf("%w: no input files", errInvalid)
+	}
+
+	inputs := make([]string, 0, len(req.GetFileIds()))
+	for _, id := range req.GetFileIds() {
+		p, err := s.filePath(id)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, p)
+	}
+
+	jobID := newID()
+	outDir := filepath.Join(s.opts.WorkDir, "jobs", jobID, "out")
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.jobs[jobID] = &entry{path: outDir, created: time.Now()}
+	s.mu.Unlock()
+
+	args := []pdftoolbox.Arg{pdftoolbox.NewOutputFolderArg(outDir)}
+
+	keys := make([]string, 0, len(req.GetVariables()))
+	for k := range req.GetVariables() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, pdftoolbox.NewSetVariableArg(k, req.GetVariables()[k]))
+	}
+
+	if req.GetTimeoutSeconds() > 0 {
+		args = append(args, pdftoolbox.NewTimeoutArg(time.Duration(req.GetTimeoutSeconds())*time.Second))
+	}
+
+	future, err := s.pool.Submit(ctx, pdftoolbox.Job{
+		Profile:    filepath.Join(s.opts.ProfileFolder, req.GetProfile()),
+		InputFiles: inputs,
+		Args:       args,
+		Priority:   int(req.GetPriority()),
+		OnEvent:    onEvent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	<-future.Done()
+	res := future.Result()
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	return toRunProfileResponse(jobID, res.Output, listArtifacts(outDir)), nil
+}
+
+func (s *Service) EnumerateProfiles(ctx context.Context, req *pdftoolboxpb.EnumerateProfilesRequest) (*pdftoolboxpb.EnumerateProfilesResponse, error) {
+	catalog, err := s.client.Catalog(s.opts.ProfileFolder)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	// A refresh runs pdfToolbox, so it takes a seat like any job
+	var resp *pdftoolbox.EnumerateProfilesResponse
+	err = s.pool.Do(ctx, func(ctx context.Context) (err error) {
+		resp, err = catalog.Response(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toEnumerateProfilesResponse(resp, catalog.Folder()), nil
+}
+
+func (s *Service) QuickCheck(ctx context.Context, req *pdftoolboxpb.QuickCheckRequest) (*pdftoolboxpb.QuickCheckResponse, error) {
+	p, err := s.filePath(req.GetFileId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	var resp *pdftoolbox.QuickCheckResponse
+	err = s.pool.Do(ctx, func(ctx context.Context) (err error) {
+		resp, err = s.client.QuickCheck(ctx, p, &pdftoolbox.QuickCheckOpts{Password: req.GetPassword()})
+		return err
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toQuickCheckResponse(resp), nil
+}
+
+// downloadChunkSize keeps messages well below the default 4 MiB limit.
+const downloadChunkSize = 256 << 10
+
+func (s *Service) Download(req *pdftoolboxpb.DownloadRequest, stream grpc.ServerStreamingServer[pdftoolboxpb.DownloadResponse]) error {
+	s.mu.Lock()
+	job, ok := s.jobs[req.GetJobId()]
+	s.mu.Unlock()
+	if !ok {
+		return toStatus(fmt.Errorf("%w: job %s", errNotFound, req.GetJobId()))
+	}
+
+	name := filepath.FromSlash(req.GetName())
+	if !filepath.IsLocal(name) {
+		return toStatus(fmt.Errorf("%w: invalid name %q", errInvalid, req.GetName()))
+	}
+
+	f, err := os.Open(filepath.Join(job.path, name))
+	if os.IsNotExist(err) {
+		return toStatus(fmt.Errorf("%w: artifact %s", errNotFound, req.GetName()))
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+	defer f.Close()
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pdftoolboxpb.DownloadResponse{Chunk: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return toStatus(err)
+		}
+	}
+}
+
+func (s *Service) Release(ctx context.Context, req *pdftoolboxpb.ReleaseRequest) (*pdftoolboxpb.ReleaseResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range req.GetFileIds() {
+		if e, ok := s.files[id]; ok {
+			os.RemoveAll(filepath.Dir(e.path))
+			delete(s.files, id)
+		}
+	}
+	for _, id := range req.GetJobIds() {
+		if e, ok := s.jobs[id]; ok {
+			os.RemoveAll(filepath.Dir(e.path))
+			delete(s.jobs, id)
+		}
+	}
+
+	return &pdftoolboxpb.ReleaseResponse{}, nil
+}
+
+func (s *Service) filePath(id string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.files[id]
+	if !ok {
+		return "", fmt.Errorf("%w: file %s", errNotFound, id)
+	}
+	return e.path, nil
+}
+
+// prune removes uploads and job outputs older than the retention period.
+func (s *Service) prune() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range []map[string]*entry{s.files, s.jobs} {
+		for id, e := range m {
+			if time.Since(e.created) > s.opts.Retention {
+				os.RemoveAll(filepath.Dir(e.path))
+				delete(m, id)
+			}
+		}
+	}
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,286 @@
+package grpcserver_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/grpcserver"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxpb"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T, exe *pdftoolboxtest.Executor) pdftoolboxpb.PDFToolboxClient {
+	t.Helper()
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, Logger: testLogger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return newTestClientWithPool(t, cl, nil)
+}
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// newTestClientWithPool serves cl over bufconn, running jobs on pool or, when
+// it is nil, on a pool created by the service.
+func newTestClientWithPool(t *testing.T, cl *pdftoolbox.Client, pool *pdftoolbox.Pool) pdftoolboxpb.PDFToolboxClient {
+	t.Helper()
+
+	svc, err := grpcserver.New(cl, &grpcserver.Opts{
+		ProfileFolder: "/profiles",
+		WorkDir:       t.TempDir(),
+		Logger:        testLogger,
+		Pool:          pool,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	svc.Register(gs)
+	go gs.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		gs.Stop()
+		svc.Close(context.Background())
+	})
+
+	return pdftoolboxpb.NewPDFToolboxClient(conn)
+}
+
+func upload(t *testing.T, c pdftoolboxpb.PDFToolboxClient, name, content string) string {
+	t.Helper()
+
+	stream, err := c.Upload(context.Background())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.NoError(t, stream.Send(&pdftoolboxpb.UploadRequest{Data: &pdftoolboxpb.UploadRequest_Name{Name: name}}))
+	// Send the content in several chunks
+	for _, chunk := range strings.SplitAfter(content, " ") {
+		assert.NoError(t, stream.Send(&pdftoolboxpb.UploadRequest{Data: &pdftoolboxpb.UploadRequest_Chunk{Chunk: []byte(chunk)}}))
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, int64(len(content)), resp.Size)
+
+	return resp.FileId
+}
+
+func TestRunProfileStream(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:      "ProcessID\t1\nStep\tPreflight\nProgress\t50\nHit\tError\tFont not embedded\nOutput\t{outputfolder}/report.pdf\nSummary\tErrors\t1\nProgress\t100\n",
+		OutputFiles: map[string][]byte{"report.pdf": []byte("%PDF-1.7 report")},
+	}
+
+	c := newTestClient(t, exe)
+	fileID := upload(t, c, "in.pdf", "%PDF-1.7 some content")
+
+	stream, err := c.RunProfileStream(context.Background(), &pdftoolboxpb.RunProfileRequest{
+		Profile:   "preflight.kfpx",
+		FileIds:   []string{fileID},
+		Variables: map[string]string{"trimWidth": "55"},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var events []*pdftoolboxpb.Event
+	var result *pdftoolboxpb.RunProfileResponse
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		if ev := update.GetEvent(); ev != nil {
+			events = append(events, ev)
+		}
+		if r := update.GetResult(); r != nil {
+			result = r
+		}
+	}
+
+	var types []pdftoolboxpb.EventType
+	for _, ev := range events {
+		types = append(types, ev.Type)
+	}
+	assert.Equal(t, []pdftoolboxpb.EventType{
+		pdftoolboxpb.EventType_EVENT_TYPE_STEP,
+		pdftoolboxpb.EventType_EVENT_TYPE_PROGRESS,
+		pdftoolboxpb.EventType_EVENT_TYPE_HIT,
+		pdftoolboxpb.EventType_EVENT_TYPE_OUTPUT,
+		pdftoolboxpb.EventType_EVENT_TYPE_SUMMARY,
+		pdftoolboxpb.EventType_EVENT_TYPE_PROGRESS,
+	}, types)
+	assert.Equal(t, "Font not embedded", events[2].Hit.Message)
+	assert.Equal(t, "Preflight", events[2].Step)
+
+	if !assert.NotNil(t, result) {
+		t.FailNow()
+	}
+	assert.Equal(t, "errors", result.Summary.Verdict)
+	assert.Len(t, result.Steps, 1)
+	if assert.Len(t, result.Artifacts, 1) {
+		assert.Equal(t, "report.pdf", result.Artifacts[0].Name)
+	}
+
+	args := exe.LastCall()
+	assert.Contains(t, args, "--setvariable=trimWidth:55")
+	assert.Contains(t, args, "/profiles/preflight.kfpx")
+
+	download, err := c.Download(context.Background(), &pdftoolboxpb.DownloadRequest{JobId: result.JobId, Name: "report.pdf"})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var buf bytes.Buffer
+	for {
+		chunk, err := download.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		buf.Write(chunk.Chunk)
+	}
+	assert.Equal(t, "%PDF-1.7 report", buf.String())
+
+	_, err = c.Release(context.Background(), &pdftoolboxpb.ReleaseRequest{FileIds: []string{fileID}, JobIds: []string{result.JobId}})
+	assert.NoError(t, err)
+
+	_, err = c.RunProfile(context.Background(), &pdftoolboxpb.RunProfileRequest{Profile: "preflight.kfpx", FileIds: []string{fileID}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRunProfileError(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:   "ProcessID\t1\nError\t1002\tCould not open file: File or folder not found\n",
+		ExitCode: 102,
+	}
+
+	c := newTestClient(t, exe)
+	fileID := upload(t, c, "in.pdf", "broken")
+
+	_, err := c.RunProfile(context.Background(), &pdftoolboxpb.RunProfileRequest{Profile: "fix.kfpx", FileIds: []string{fileID}})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, grpcserver.ErrorDomain, info.Domain)
+		assert.Equal(t, "1002", info.Metadata["code"])
+		assert.Equal(t, "input", info.Metadata["class"])
+	}
+
+	_, err = c.RunProfile(context.Background(), &pdftoolboxpb.RunProfileRequest{Profile: "../fix.kfpx", FileIds: []string{fileID}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestQuickCheck(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\n",
+		Run: func(args []string) error {
+			for _, a := range args {
+				if path, ok := strings.CutPrefix(a, "--outputfile="); ok {
+					return os.WriteFile(path, []byte(`{"document": {"page_count": 2},
+  "pages": [{"number": 1, "trimbox": {"left": 0, "bottom": 0, "right": 595.276, "top": 841.89}}]}`), 0o644)
+				}
+			}
+			return nil
+		},
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, Logger: testLogger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	pool := pdftoolbox.NewPool(cl, &pdftoolbox.PoolOpts{Concurrency: 1})
+	defer pool.Shutdown(context.Background())
+
+	c := newTestClientWithPool(t, cl, pool)
+	fileID := upload(t, c, "in.pdf", "%PDF-1.7")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.QuickCheck(ctx, &pdftoolboxpb.QuickCheckRequest{FileId: fileID})
+	if !assert.NoError(t, err) || !assert.Len(t, resp.Pages, 1) {
+		t.FailNow()
+	}
+	assert.Equal(t, int32(2), resp.PageCount)
+	assert.InDelta(t, 210, resp.Pages[0].TrimBox.WidthMm, 0.01)
+
+	// The check took a seat on the shared pool
+	assert.Equal(t, uint64(1), pool.Metrics().Completed)
+}
+
+func TestRunProfileStreamCancelsJob(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:    "Step\tPreflight\nProgress\t100\n",
+		LineDelay: time.Minute,
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, Logger: testLogger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	pool := pdftoolbox.NewPool(cl, &pdftoolbox.PoolOpts{Concurrency: 1})
+	defer pool.Shutdown(context.Background())
+
+	c := newTestClientWithPool(t, cl, pool)
+	fileID := upload(t, c, "in.pdf", "%PDF-1.7")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.RunProfileStream(ctx, &pdftoolboxpb.RunProfileRequest{Profile: "preflight.kfpx", FileIds: []string{fileID}})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+
+	// The client goes away while pdfToolbox is still running
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		m := pool.Metrics()
+		return m.Running == 0 && m.Failed == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,558 @@
+// Package hotfolder runs pdfToolbox profiles on files dropped into watched
+// folders.
+//
+// Input folders are polled rather than watched with inotify so that they may
+// live on network shares. A file is picked up once its size and modification
+// time have stopped changing, and is moved into a job folder under
+// Output/processing while it runs. When the job ends the job folder, holding
+// the input, an out folder with the files pdfToolbox wrote and a result.json,
+// is moved to Output/success or Output/error. Job folders left in processing
+// by a crash or restart are run again when the watcher starts.
+package hotfolder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+const (
+	processingDir = "processing"
+	successDir    = "success"
+	errorDir      = "error"
+	outDir        = "out"
+	resultFile    = "result.json"
+	// partialPrefix starts the name of a file moveFile is still copying
+	partialPrefix = ".partial-"
+)
+
+// Folder maps an input folder to the profile its files are run with.
+type Folder struct {
+	Input   string `json:"input"`
+	Profile string `json:"profile"`
+	// Output holds the processing, success and error folders. Defaults to
+	// the input folder.
+	Output    string            `json:"output"`
+	Variables map[string]string `json:"variables"`
+	// Patterns are matched case-insensitively against file names. Defaults
+	// to *.pdf.
+	Patterns []string         `json:"patterns"`
+	Args     []pdftoolbox.Arg `json:"args"`
+}
+
+func (f Folder) output() string {
+	if f.Output != "" {
+		return f.Output
+	}
+	return f.Input
+}
+
+func (f Folder) matches(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	patterns := f.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"*.pdf"}
+	}
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(strings.ToLower(p), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Folder) args(jobOut string) []pdftoolbox.Arg {
+	args := append([]pdftoolbox.Arg{pdftoolbox.NewOutputFolderArg(jobOut)}, f.Args...)
+
+	keys := make([]string, 0, len(f.Variables))
+	for k := range f.Variables {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, pdftoolbox.NewSetVariableArg(k, f.Variables[k]))
+	}
+
+	return args
+}
+
+type Status string
+
+const (
+	StatusSuccess Status = "success"
+	StatusError   Status = "error"
+)
+
+// Result is written as result.json into the folder of every finished job.
+type Result struct {
+	Input      string                `json:"input"`
+	Profile    string                `json:"profile"`
+	Status     Status                `json:"status"`
+	StartedAt  time.Time             `json:"startedAt"`
+	FinishedAt time.Time             `json:"finishedAt"`
+	Output     *pdftoolbox.CmdOutput `json:"output,omitempty"`
+	Error      string                `json:"error,omitempty"`
+	// JobFolder is where the job folder was moved to
+	JobFolder string `json:"jobFolder"`
+}
+
+type Opts struct {
+	// Interval is how often input folders are scanned. Defaults to two
+	// seconds.
+	Interval time.Duration
+	// StableFor is how long a file's size and modification time must stay
+	// the same before it is picked up. Defaults to five seconds.
+	StableFor time.Duration
+	// Concurrency is the number of jobs run at once. Defaults to 1.
+	Concurrency int
+	Logger      *slog.Logger
+	// OnResult is called after each job folder has been moved.
+	OnResult func(Result)
+}
+
+type Watcher struct {
+	client  pdftoolbox.PDFToolboxClient
+	folders []Folder
+	opts    Opts
+
+	mu       sync.Mutex
+	pending  map[string]fileState
+	finishMu sync.Mutex
+	wg       sync.WaitGroup
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+	since   time.Time
+}
+
+func New(client pdftoolbox.PDFToolboxClient, folders []Folder, opts *Opts) (*Watcher, error) {
+	w := &Watcher{
+		client:  client,
+		folders: folders,
+		opts: Opts{
+			Interval:    2 * time.Second,
+			StableFor:   5 * time.Second,
+			Concurrency: 1,
+			Logger:      slog.Default(),
+		},
+		pending: map[string]fileState{},
+	}
+
+	if opts != nil {
+		if opts.Interval > 0 {
+			w.opts.Interval = opts.Interval
+		}
+		if opts.StableFor > 0 {
+			w.opts.StableFor = opts.StableFor
+		}
+		if opts.Concurrency > 0 {
+			w.opts.Concurrency = opts.Concurrency
+		}
+		if opts.Logger != nil {
+			w.opts.Logger = opts.Logger
+		}
+		w.opts.OnResult = opts.OnResult
+	}
+
+	if len(folders) == 0 {
+		return nil, errors.New("hotfolder: no folders configured")
+	}
+	for _, f := range folders {
+		if f.Input == "" || f.Profile == "" {
+			return nil, fmt.Errorf("hotfolder: folder %q needs an input folder and a profile", f.Input)
+		}
+		for _, dir := range []string{f.Input, filepath.Join(f.output(), processingDir), filepath.Join(f.output(), successDir), filepath.Join(f.output(), errorDir)} {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return w, nil
+}
+
+// Run watches the folders until ctx is done. Jobs that are still running
+// then are killed and left in the processing folder, to be run again the
+// next time the watcher starts.
+func (w *Watcher) Run(ctx context.Context) error {
+	pool := pdftoolbox.NewPool(w.client, &pdftoolbox.PoolOpts{Concurrency: w.opts.Concurrency})
+	defer func() {
+		w.wg.Wait()
+		pool.Shutdown(context.Background())
+	}()
+
+	for _, f := range w.folders {
+		if err := w.recover(ctx, pool, f); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, f := range w.folders {
+			if err := w.scan(ctx, pool, f); err != nil {
+				w.opts.Logger.Error("scanning hot folder", "folder", f.Input, "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// recover submits the job folders left in processing.
+func (w *Watcher) recover(ctx context.Context, pool *pdftoolbox.Pool, f Folder) error {
+	processing := filepath.Join(f.output(), processingDir)
+	entries, err := os.ReadDir(processing)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		jobDir := filepath.Join(processing, e.Name())
+		input, err := jobInput(jobDir)
+		if err != nil {
+			w.opts.Logger.Error("recovering job", "job", jobDir, "error", err)
+			continue
+		}
+
+		// Outputs of the interrupted run are incomplete
+		if err := os.RemoveAll(filepath.Join(jobDir, outDir)); err != nil {
+			return err
+		}
+
+		w.opts.Logger.Info("recovering job", "job", jobDir)
+		w.submit(ctx, pool, f, jobDir, input, input)
+	}
+
+	return nil
+}
+
+// jobInput returns the input file of a job folder.
+func jobInput(jobDir string) (string, error) {
+	entries, err := os.ReadDir(jobDir)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if e.Type().IsRegular() && e.Name() != resultFile && !strings.HasPrefix(e.Name(), partialPrefix) {
+			return filepath.Join(jobDir, e.Name()), nil
+		}
+	}
+	return "", errors.New("hotfolder: job folder has no input file")
+}
+
+// scan picks up the files of f that have stopped changing.
+func (w *Watcher) scan(ctx context.Context, pool *pdftoolbox.Pool, f Folder) error {
+	entries, err := os.ReadDir(f.Input)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	seen := map[string]bool{}
+
+	for _, e := range entries {
+		if !e.Type().IsRegular() || !f.matches(e.Name()) {
+			continue
+		}
+
+		path := filepath.Join(f.Input, e.Name())
+		seen[path] = true
+
+		fi, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if !w.stable(path, fi, now) {
+			continue
+		}
+
+		jobDir, input, err := claim(f, path)
+		if err != nil {
+			w.opts.Logger.Error("claiming file", "file", path, "error", err)
+			continue
+		}
+		w.submit(ctx, pool, f, jobDir, input, path)
+	}
+
+	// Forget files that have disappeared
+	w.mu.Lock()
+	for path := range w.pending {
+		if strings.HasPrefix(path, f.Input+string(filepath.Separator)) && !seen[path] {
+			delete(w.pending, path)
+		}
+	}
+	w.mu.Unlock()
+
+	return nil
+}
+
+// stable reports whether the file at path has had the same size and
+// modification time for StableFor.
+func (w *Watcher) stable(path string, fi os.FileInfo, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	st, ok := w.pending[path]
+	if !ok || st.size != fi.Size() || !st.modTime.Equal(fi.ModTime()) {
+		w.pending[path] = fileState{size: fi.Size(), modTime: fi.ModTime(), since: now}
+		return false
+	}
+	if now.Sub(st.since) < w.opts.StableFor {
+		return false
+	}
+
+	delete(w.pending, path)
+	return true
+}
+
+// claim moves path into a new job folder in processing.
+func claim(f Folder, path string) (string, string, error) {
+	name := filepath.Base(path)
+	jobDir, err := uniqueDir(filepath.Join(f.output(), processingDir), strings.TrimSuffix(name, filepath.Ext(name)))
+	if err != nil {
+		return "", "", err
+	}
+
+	input := filepath.Join(jobDir, name)
+	if err := moveFile(path, input); err != nil {
+		os.Remove(jobDir)
+		return "", "", err
+	}
+
+	return jobDir, input, nil
+}
+
+func (w *Watcher) submit(ctx context.Context, pool *pdftoolbox.Pool, f Folder, jobDir, input, original string) {
+	jobOut := filepath.Join(jobDir, outDir)
+	startedAt := time.Now()
+
+	var future *pdftoolbox.Future
+	err := os.MkdirAll(jobOut, 0o755)
+	if err == nil {
+		future, err = pool.Submit(ctx, pdftoolbox.Job{
+			Profile:    f.Profile,
+			InputFiles: []string{input},
+			Args:       f.args(jobOut),
+		})
+	}
+	if err != nil {
+		// The job stays in processing and is retried on the next start
+		w.opts.Logger.Error("submitting job", "job", jobDir, "error", err)
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		<-future.Done()
+
+		res := future.Result()
+		if res.Err != nil && ctx.Err() != nil {
+			w.opts.Logger.Info("job interrupted, leaving it for recovery", "job", jobDir)
+			return
+		}
+
+		if err := w.finish(f, jobDir, original, startedAt, res); err != nil {
+			w.opts.Logger.Error("finishing job", "job", jobDir, "error", err)
+		}
+	}()
+}
+
+// finish writes result.json and moves the job folder to success or error.
+func (w *Watcher) finish(f Folder, jobDir, original string, startedAt time.Time, res pdftoolbox.JobResult) error {
+	result := Result{
+		Input:      original,
+		Profile:    f.Profile,
+		Status:     StatusSuccess,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+	}
+	if res.Err != nil {
+		result.Status = StatusError
+		result.Error = res.Err.Error()
+	}
+
+	// Jobs finish concurrently; hold the lock from picking the name of the
+	// target folder until the job folder has been moved there.
+	w.finishMu.Lock()
+	defer w.finishMu.Unlock()
+
+	target := unusedPath(filepath.Join(f.output(), string(result.Status)), filepath.Base(jobDir))
+	result.JobFolder = target
+
+	// Failed jobs keep whatever output pdfToolbox produced before failing;
+	// jobs that failed before it ran have none.
+	out := res.Output
+	var pe *pdftoolbox.ParsedError
+	if out.Raw == "" && errors.As(res.Err, &pe) {
+		out.Raw = pe.RawOutput
+		out.ExitCode = pe.ProcessExitCode
+	}
+	if res.Err == nil || out.Raw != "" || len(out.Attempts) > 0 {
+		out = rebaseOutput(out, jobDir, target)
+		result.Output = &out
+	}
+
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(jobDir, resultFile), b, 0o644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(jobDir, target); err != nil {
+		return err
+	}
+
+	w.opts.Logger.Info("job finished", "input", original, "status", result.Status, "folder", target)
+	if w.opts.OnResult != nil {
+		w.opts.OnResult(result)
+	}
+	return nil
+}
+
+// rebaseOutput returns out with the paths inside the folder from pointing
+// into the folder to instead.
+func rebaseOutput(out pdftoolbox.CmdOutput, from, to string) pdftoolbox.CmdOutput {
+	out.OutputFolder = rebase(out.OutputFolder, from, to)
+
+	out.Artifacts = append([]pdftoolbox.Artifact(nil), out.Artifacts...)
+	for i, a := range out.Artifacts {
+		out.Artifacts[i].Path = rebase(a.Path, from, to)
+	}
+
+	out.Steps = append([]pdftoolbox.CmdStepOutput(nil), out.Steps...)
+	for i, step := range out.Steps {
+		paths := make([]string, len(step.OutputFilePaths))
+		for j, p := range step.OutputFilePaths {
+			paths[j] = rebase(p, from, to)
+		}
+		out.Steps[i].OutputFilePaths = paths
+	}
+
+	if out.Report != nil {
+		report := *out.Report
+		report.Document.Path = rebase(report.Document.Path, from, to)
+		out.Report = &report
+	}
+
+	return out
+}
+
+func rebase(path, from, to string) string {
+	if path == "" {
+		return path
+	}
+
+	rel, err := filepath.Rel(from, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return path
+	}
+	return filepath.Join(to, rel)
+}
+
+// uniqueDir creates and returns dir/name, or dir/name-2, dir/name-3 ... if
+// it already exists.
+func uniqueDir(dir, name string) (string, error) {
+	for i := 1; ; i++ {
+		p := numbered(dir, name, i)
+
+		err := os.Mkdir(p, 0o755)
+		if err == nil {
+			return p, nil
+		}
+		if !os.IsExist(err) {
+			return "", err
+		}
+	}
+}
+
+// unusedPath returns the first of dir/name, dir/name-2, dir/name-3 ... that
+// does not exist.
+func unusedPath(dir, name string) string {
+	for i := 1; ; i++ {
+		p := numbered(dir, name, i)
+		if _, err := os.Lstat(p); os.IsNotExist(err) {
+			return p
+		}
+	}
+}
+
+func numbered(dir, name string, i int) string {
+	if i > 1 {
+		name = fmt.Sprintf("%s-%d", name, i)
+	}
+	return filepath.Join(dir, name)
+}
+
+// moveFile renames src to dst, copying it when they are on different file
+// systems.
+func moveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// Copy to a temporary name next to dst so that dst only ever appears
+	// complete
+	out, err := os.CreateTemp(filepath.Dir(dst), partialPrefix+"*")
+	if err != nil {
+		return err
+	}
+	if fi, err := in.Stat(); err == nil {
+		out.Chmod(fi.Mode().Perm())
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return err
+	}
+	if err := os.Rename(out.Name(), dst); err != nil {
+		os.Remove(out.Name())
+		return err
+	}
+
+	return os.Remove(src)
+}
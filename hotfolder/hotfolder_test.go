@@ -0,0 +1,191 @@
+package hotfolder_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/hotfolder"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type results struct {
+	mu sync.Mutex
+	rs []hotfolder.Result
+}
+
+func (r *results) add(res hotfolder.Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rs = append(r.rs, res)
+}
+
+func (r *results) get() []hotfolder.Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]hotfolder.Result(nil), r.rs...)
+}
+
+func startWatcher(t *testing.T, exe *pdftoolboxtest.Executor, folder hotfolder.Folder) *results {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, Logger: logger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	rs := &results{}
+	w, err := hotfolder.New(cl, []hotfolder.Folder{folder}, &hotfolder.Opts{
+		Interval:  5 * time.Millisecond,
+		StableFor: 20 * time.Millisecond,
+		Logger:    logger,
+		OnResult:  rs.add,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return rs
+}
+
+func TestWatcherSuccess(t *testing.T) {
+	dir := t.TempDir()
+	input, output := filepath.Join(dir, "in"), filepath.Join(dir, "out")
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:      "ProcessID\t1\nStep\tFix\nOutput\t{outputfolder}/flyer_fixed.pdf\n",
+		OutputFiles: map[string][]byte{"flyer_fixed.pdf": []byte("%PDF-1.7 fixed")},
+	}
+
+	rs := startWatcher(t, exe, hotfolder.Folder{
+		Input:     input,
+		Output:    output,
+		Profile:   "/profiles/fix.kfpx",
+		Variables: map[string]string{"trimWidth": "55"},
+	})
+
+	assert.NoError(t, os.WriteFile(filepath.Join(input, "flyer.pdf"), []byte("%PDF-1.7"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(input, "notes.txt"), []byte("ignored"), 0o644))
+
+	assert.Eventually(t, func() bool { return len(rs.get()) == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	res := rs.get()[0]
+	jobDir := filepath.Join(output, "success", "flyer")
+	assert.Equal(t, hotfolder.StatusSuccess, res.Status)
+	assert.Equal(t, jobDir, res.JobFolder)
+	assert.FileExists(t, filepath.Join(jobDir, "flyer.pdf"))
+	assert.FileExists(t, filepath.Join(jobDir, "out", "flyer_fixed.pdf"))
+	assert.FileExists(t, filepath.Join(input, "notes.txt"))
+	assert.NoFileExists(t, filepath.Join(input, "flyer.pdf"))
+
+	b, err := os.ReadFile(filepath.Join(jobDir, "result.json"))
+	if assert.NoError(t, err) {
+		var written hotfolder.Result
+		assert.NoError(t, json.Unmarshal(b, &written))
+		assert.Equal(t, filepath.Join(input, "flyer.pdf"), written.Input)
+		if assert.NotNil(t, written.Output) && assert.Len(t, written.Output.Artifacts, 1) {
+			assert.Equal(t, filepath.Join(jobDir, "out", "flyer_fixed.pdf"), written.Output.Artifacts[0].Path)
+		}
+		if assert.NotNil(t, written.Output) && assert.Len(t, written.Output.Steps, 1) {
+			assert.Equal(t, []string{filepath.Join(jobDir, "out", "flyer_fixed.pdf")}, written.Output.Steps[0].OutputFilePaths)
+		}
+	}
+
+	assert.Contains(t, exe.LastCall(), "--setvariable=trimWidth:55")
+}
+
+func TestWatcherError(t *testing.T) {
+	dir := t.TempDir()
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:   "ProcessID\t1\nError\t1006\tDocument is damaged\n",
+		ExitCode: 105,
+	}
+
+	rs := startWatcher(t, exe, hotfolder.Folder{Input: dir, Profile: "/profiles/fix.kfpx"})
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.PDF"), []byte("x"), 0o644))
+	assert.Eventually(t, func() bool { return len(rs.get()) == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	res := rs.get()[0]
+	assert.Equal(t, hotfolder.StatusError, res.Status)
+	assert.Contains(t, res.Error, "damaged")
+	assert.FileExists(t, filepath.Join(dir, "error", "broken", "broken.PDF"))
+	assert.FileExists(t, filepath.Join(dir, "error", "broken", "result.json"))
+
+	// The output pdfToolbox produced before failing is kept
+	if assert.NotNil(t, res.Output) {
+		assert.Equal(t, 105, res.Output.ExitCode)
+		assert.Contains(t, res.Output.Raw, "Document is damaged")
+	}
+}
+
+func TestWatcherWaitsForStableFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n"}
+
+	rs := startWatcher(t, exe, hotfolder.Folder{Input: dir, Profile: "/profiles/fix.kfpx"})
+
+	// Keep appending to the file for longer than StableFor
+	path := filepath.Join(dir, "growing.pdf")
+	f, err := os.Create(path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	for i := 0; i < 10; i++ {
+		f.WriteString("more ")
+		time.Sleep(10 * time.Millisecond)
+		assert.FileExists(t, path)
+	}
+	f.Close()
+
+	assert.Eventually(t, func() bool { return len(rs.get()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	b, err := os.ReadFile(filepath.Join(dir, "success", "growing", "growing.pdf"))
+	assert.NoError(t, err)
+	assert.Len(t, b, 50)
+}
+
+func TestWatcherRecoversPendingJobs(t *testing.T) {
+	dir := t.TempDir()
+
+	// A job interrupted by a restart, with incomplete output
+	jobDir := filepath.Join(dir, "processing", "leftover")
+	assert.NoError(t, os.MkdirAll(filepath.Join(jobDir, "out"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(jobDir, "leftover.pdf"), []byte("%PDF-1.7"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(jobDir, "out", "partial.pdf"), []byte("%PDF"), 0o644))
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n"}
+
+	rs := startWatcher(t, exe, hotfolder.Folder{Input: dir, Profile: "/profiles/fix.kfpx"})
+
+	assert.Eventually(t, func() bool { return len(rs.get()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, hotfolder.StatusSuccess, rs.get()[0].Status)
+	assert.FileExists(t, filepath.Join(dir, "success", "leftover", "leftover.pdf"))
+	assert.NoFileExists(t, filepath.Join(dir, "success", "leftover", "out", "partial.pdf"))
+	assert.NoDirExists(t, jobDir)
+}
@@ -0,0 +1,224 @@
+package pdftoolbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ImageFormat string
+
+const (
+	ImagePNG  ImageFormat = "PNG"
+	ImageJPEG ImageFormat = "JPEG"
+	ImageTIFF ImageFormat = "TIFF"
+)
+
+type ImageColorSpace string
+
+const (
+	ImageRGB  ImageColorSpace = "RGB"
+	ImageCMYK ImageColorSpace = "CMYK"
+	ImageGray ImageColorSpace = "GRAY"
+)
+
+type SaveAsImageOpts struct {
+	// Format defaults to PNG
+	Format ImageFormat
+	// Resolution in dpi, pdfToolbox's default is used when zero
+	Resolution int
+	ColorSpace ImageColorSpace
+	// Pages is a page range such as "1-3,5". Empty renders every page.
+	Pages string
+	// AntiAliasing smooths text, line art and images
+	AntiAliasing bool
+	// FileNamePattern names the images; pdfToolbox appends the page number.
+	FileNamePattern string
+	// OutputFolder receives the images. When empty a temporary folder is
+	// created, which the caller must remove.
+	OutputFolder string
+	Timeout      time.Duration
+}
+
+type RenderedImage struct {
+	Page int    `json:"page"`
+	Path string `json:"path"`
+}
+
+func (o SaveAsImageOpts) args() []Arg {
+	format := o.Format
+	if format == "" {
+		format = ImagePNG
+	}
+
+	args := []Arg{
+		{Arg: "--saveasimg"},
+		newStringArg("--imgformat", string(format)),
+	}
+	if o.Resolution > 0 {
+		args = append(args, newStringArg("--resolution", strconv.Itoa(o.Resolution)))
+	}
+	if o.ColorSpace != "" {
+		args = append(args, newStringArg("--colorspace", string(o.ColorSpace)))
+	}
+	if o.Pages != "" {
+		args = append(args, newStringArg("--pagerange", o.Pages))
+	}
+	if o.AntiAliasing {
+		args = append(args, newStringArg("--smoothing", "ALL"))
+	}
+	if o.FileNamePattern != "" {
+		args = append(args, NewOutputFileArg(o.FileNamePattern))
+	}
+	if o.Timeout > 0 {
+		args = append(args, NewTimeoutArg(o.Timeout))
+	}
+
+	return args
+}
+
+// SaveAsImage renders the pages of input to images with --saveasimg and
+// returns them ordered by page. Only images written by this run are
+// returned, files already in OutputFolder are left out.
+func (cl *Client) SaveAsImage(ctx context.Context, input string, opts SaveAsImageOpts) (images []RenderedImage, err error) {
+	if err := cl.require(ctx, CapSaveAsImage); err != nil {
+		return nil, err
+	}
+
+	if _, err := expandPageRange(opts.Pages, 0); err != nil {
+		return nil, err
+	}
+
+	folder := opts.OutputFolder
+	var before folderSnapshot
+	if folder == "" {
+		if folder, err = os.MkdirTemp("", "pdftoolbox-images-"); err != nil {
+			return nil, err
+		}
+		// The folder is only handed out with the images in it
+		defer func() {
+			if err != nil {
+				os.RemoveAll(folder)
+			}
+		}()
+	} else if before, err = snapshotFolder(folder); err != nil {
+		return nil, err
+	}
+
+	cmd := argStrings(append(opts.args(), NewOutputFolderArg(folder)))
+	cmd = append(cmd, input)
+
+	output, err := cl.runCmd(ctx, cl.seatArgs(cmd)...)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := collectArtifacts(output.Artifacts, folder, before)
+	if err != nil {
+		return nil, err
+	}
+
+	// pdfToolbox writes one image per rendered page and never more images
+	// than the document has pages, so the range only needs expanding as far
+	// as the images that were written.
+	pages, err := expandPageRange(opts.Pages, len(artifacts))
+	if err != nil {
+		return nil, err
+	}
+
+	return renderedImages(artifacts, pages), nil
+}
+
+// renderedImages orders the images by file name, comparing embedded numbers
+// by value, and gives them the requested pages in that order. Without a
+// range every page was rendered, starting at page 1.
+func renderedImages(artifacts []Artifact, pages []int) []RenderedImage {
+	paths := artifactPaths(artifacts)
+	sort.SliceStable(paths, func(i, j int) bool {
+		return naturalLess(filepath.Base(paths[i]), filepath.Base(paths[j]))
+	})
+
+	images := make([]RenderedImage, 0, len(paths))
+	for i, path := range paths {
+		img := RenderedImage{Path: path, Page: i + 1}
+		if pages != nil && i < len(pages) {
+			img.Page = pages[i]
+		}
+		images = append(images, img)
+	}
+
+	return images
+}
+
+// naturalLess compares a and b with runs of digits compared by value, so
+// that "page_2" sorts before "page_10".
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		da, db := leadingDigits(a), leadingDigits(b)
+		if da != "" && db != "" {
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// expandPageRange turns "1-3,5" into [1 2 3 5], stopping after max pages.
+// The whole range is validated even when max is reached. An empty range
+// returns nil.
+func expandPageRange(s string, max int) ([]int, error) {
+	var pages []int
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(from)
+		if err != nil || start < 1 {
+			return nil, fmt.Errorf("pdftoolbox: invalid page range %q", s)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(to); err != nil || end < start {
+				return nil, fmt.Errorf("pdftoolbox: invalid page range %q", s)
+			}
+		}
+
+		for p := start; p <= end && len(pages) < max; p++ {
+			pages = append(pages, p)
+		}
+	}
+
+	return pages, nil
+}
+
+func newStringArg(name, value string) Arg {
+	return Arg{Arg: name, Value: &value}
+}
@@ -0,0 +1,129 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAsImage(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "Output\t{outputfolder}/proof_0003.png\nOutput\t{outputfolder}/proof_0002.png\nDuration\t00:01\n",
+		OutputFiles: map[string][]byte{
+			"proof_0002.png": []byte("\x89PNG"),
+			"proof_0003.png": []byte("\x89PNG"),
+		},
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dir := t.TempDir()
+	images, err := cl.SaveAsImage(context.Background(), "in.pdf", pdftoolbox.SaveAsImageOpts{
+		Resolution:      150,
+		ColorSpace:      pdftoolbox.ImageRGB,
+		Pages:           "2-3",
+		AntiAliasing:    true,
+		FileNamePattern: "proof",
+		OutputFolder:    dir,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, []pdftoolbox.RenderedImage{
+		{Page: 2, Path: filepath.Join(dir, "proof_0002.png")},
+		{Page: 3, Path: filepath.Join(dir, "proof_0003.png")},
+	}, images)
+
+	assert.Equal(t, []string{
+		"--saveasimg",
+		"--imgformat=PNG",
+		"--resolution=150",
+		"--colorspace=RGB",
+		"--pagerange=2-3",
+		"--smoothing=ALL",
+		"--outputfile=proof",
+		"--outputfolder=" + dir,
+		"in.pdf",
+	}, exe.LastCall())
+}
+
+func TestSaveAsImageInvalidPageRange(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Duration\t00:01\n"}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.SaveAsImage(context.Background(), "in.pdf", pdftoolbox.SaveAsImageOpts{Pages: "3-1", OutputFolder: t.TempDir()})
+	assert.ErrorContains(t, err, "invalid page range")
+}
+
+func TestSaveAsImageSkipsExistingFiles(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "Duration\t00:01\n",
+		OutputFiles: map[string][]byte{
+			"proof300_1.png":  []byte("\x89PNG"),
+			"proof300_2.png":  []byte("\x89PNG"),
+			"proof300_10.png": []byte("\x89PNG"),
+		},
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "cover.png"), []byte("\x89PNG"), 0o644))
+
+	images, err := cl.SaveAsImage(context.Background(), "in.pdf", pdftoolbox.SaveAsImageOpts{
+		Pages:        "4,7-1000000000",
+		OutputFolder: dir,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, []pdftoolbox.RenderedImage{
+		{Page: 4, Path: filepath.Join(dir, "proof300_1.png")},
+		{Page: 7, Path: filepath.Join(dir, "proof300_2.png")},
+		{Page: 8, Path: filepath.Join(dir, "proof300_10.png")},
+	}, images)
+}
+
+func TestSaveAsImageRemovesFolderOnFailure(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Error\t1006\tPDF is damaged\n", ExitCode: 105}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.SaveAsImage(context.Background(), "in.pdf", pdftoolbox.SaveAsImageOpts{})
+	assert.ErrorIs(t, err, pdftoolbox.ErrDamaged)
+
+	var folder string
+	for _, a := range exe.LastCall() {
+		if v, ok := strings.CutPrefix(a, "--outputfolder="); ok {
+			folder = v
+		}
+	}
+	if assert.NotEmpty(t, folder) {
+		assert.NoDirExists(t, folder)
+	}
+}
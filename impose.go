@@ -0,0 +1,328 @@
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// SheetSize is a press sheet size in millimetres.
+type SheetSize struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+var (
+	SheetA4   = SheetSize{Width: 210, Height: 297}
+	SheetA3   = SheetSize{Width: 297, Height: 420}
+	SheetSRA3 = SheetSize{Width: 320, Height: 450}
+)
+
+type ImposeMode string
+
+const (
+	// StepAndRepeat fills the sheet with copies of the same page.
+	StepAndRepeat ImposeMode = "stepandrepeat"
+	// NUp places consecutive pages on the sheet.
+	NUp ImposeMode = "nup"
+)
+
+type PageOrder string
+
+const (
+	OrderRows    PageOrder = "rows"
+	OrderColumns PageOrder = "columns"
+)
+
+type Mark string
+
+const (
+	CropMarks         Mark = "crop"
+	RegistrationMarks Mark = "registration"
+	ColorBars         Mark = "colorbars"
+)
+
+// Margins are in millimetres.
+type Margins struct {
+	Top    float64 `json:"top"`
+	Right  float64 `json:"right"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+}
+
+// ImposeOpts describes an imposition. All lengths are in millimetres.
+type ImposeOpts struct {
+	// Profile is the imposition profile to run. Its imposition fixup must
+	// take the layout from the variables named by the ImposeVar constants.
+	Profile string `json:"profile"`
+	// Mode defaults to StepAndRepeat
+	Mode    ImposeMode `json:"mode"`
+	Sheet   SheetSize  `json:"sheet"`
+	Margins Margins    `json:"margins"`
+	// GutterX and GutterY are the gaps between items
+	GutterX float64 `json:"gutterX"`
+	GutterY float64 `json:"gutterY"`
+	// Rows and Columns fix the grid. When zero, as many as fit are used.
+	Rows    int `json:"rows"`
+	Columns int `json:"columns"`
+	// ItemWidth and ItemHeight are the trim size of a placed page
+	ItemWidth  float64 `json:"itemWidth"`
+	ItemHeight float64 `json:"itemHeight"`
+	// Bleed is added around each item, outside its trim size. The gutters
+	// are the gaps between the bleed edges of neighbouring items.
+	Bleed float64 `json:"bleed"`
+	// AllowRotation turns items by 90 degrees when more of them fit that way
+	AllowRotation bool   `json:"allowRotation"`
+	Marks         []Mark `json:"marks"`
+	// Order defaults to OrderRows
+	Order PageOrder `json:"order"`
+}
+
+// ImposeLayout is the computed placement of items on a sheet. Cell
+// positions are the trim boxes, measured from the bottom left of the sheet.
+type ImposeLayout struct {
+	Rows     int          `json:"rows"`
+	Columns  int          `json:"columns"`
+	PerSheet int          `json:"perSheet"`
+	Rotated  bool         `json:"rotated"`
+	Cells    []LayoutCell `json:"cells"`
+	// Utilisation is the fraction of the sheet covered by trimmed items
+	Utilisation float64 `json:"utilisation"`
+}
+
+type LayoutCell struct {
+	Index  int     `json:"index"`
+	Row    int     `json:"row"`
+	Column int     `json:"column"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// SheetsNeeded returns the number of sheets needed for quantity items.
+func (l ImposeLayout) SheetsNeeded(quantity int) int {
+	if l.PerSheet == 0 {
+		return 0
+	}
+	return (quantity + l.PerSheet - 1) / l.PerSheet
+}
+
+// PreviewLayout computes the layout pdfToolbox will be asked to produce,
+// without running it. The grid is centred in the area inside the margins.
+func PreviewLayout(opts ImposeOpts) (ImposeLayout, error) {
+	if opts.Sheet.Width <= 0 || opts.Sheet.Height <= 0 {
+		return ImposeLayout{}, errors.New("pdftoolbox: impose needs a sheet size")
+	}
+	if opts.ItemWidth <= 0 || opts.ItemHeight <= 0 {
+		return ImposeLayout{}, errors.New("pdftoolbox: impose needs an item size")
+	}
+
+	availW := opts.Sheet.Width - opts.Margins.Left - opts.Margins.Right
+	availH := opts.Sheet.Height - opts.Margins.Top - opts.Margins.Bottom
+
+	itemW, itemH := opts.ItemWidth, opts.ItemHeight
+	cols, rows := fitGrid(opts, availW, availH, itemW, itemH)
+	fits := gridFits(opts, availW, availH, cols, rows, itemW, itemH)
+	rotated := false
+
+	if opts.AllowRotation {
+		rCols, rRows := fitGrid(opts, availW, availH, itemH, itemW)
+		rFits := gridFits(opts, availW, availH, rCols, rRows, itemH, itemW)
+		if rFits && (!fits || rCols*rRows > cols*rows) {
+			cols, rows, fits, rotated = rCols, rRows, true, true
+			itemW, itemH = itemH, itemW
+		}
+	}
+
+	if cols == 0 || rows == 0 {
+		return ImposeLayout{}, fmt.Errorf("pdftoolbox: a %gx%g mm item does not fit on a %gx%g mm sheet", opts.ItemWidth, opts.ItemHeight, opts.Sheet.Width, opts.Sheet.Height)
+	}
+	if !fits {
+		return ImposeLayout{}, fmt.Errorf("pdftoolbox: %d x %d items do not fit on the sheet", cols, rows)
+	}
+
+	cellW := itemW + 2*opts.Bleed
+	cellH := itemH + 2*opts.Bleed
+	usedW := float64(cols)*cellW + float64(cols-1)*opts.GutterX
+	usedH := float64(rows)*cellH + float64(rows-1)*opts.GutterY
+
+	left := opts.Margins.Left + (availW-usedW)/2
+	top := opts.Sheet.Height - opts.Margins.Top - (availH-usedH)/2
+
+	layout := ImposeLayout{
+		Rows:     rows,
+		Columns:  cols,
+		PerSheet: rows * cols,
+		Rotated:  rotated,
+	}
+
+	for i := 0; i < layout.PerSheet; i++ {
+		row, col := i/cols, i%cols
+		if opts.Order == OrderColumns {
+			row, col = i%rows, i/rows
+		}
+
+		layout.Cells = append(layout.Cells, LayoutCell{
+			Index:  i,
+			Row:    row,
+			Column: col,
+			X:      left + float64(col)*(cellW+opts.GutterX) + opts.Bleed,
+			Y:      top - float64(row)*(cellH+opts.GutterY) - cellH + opts.Bleed,
+			Width:  itemW,
+			Height: itemH,
+		})
+	}
+
+	layout.Utilisation = float64(layout.PerSheet) * itemW * itemH / (opts.Sheet.Width * opts.Sheet.Height)
+
+	return layout, nil
+}
+
+// fitGrid returns the columns and rows that fit, honouring fixed values.
+func fitGrid(opts ImposeOpts, availW, availH, itemW, itemH float64) (int, int) {
+	fit := func(avail, item, gutter float64) int {
+		n := math.Floor((avail + gutter) / (item + 2*opts.Bleed + gutter))
+		return max(int(n), 0)
+	}
+
+	cols, rows := opts.Columns, opts.Rows
+	if cols == 0 {
+		cols = fit(availW, itemW, opts.GutterX)
+	}
+	if rows == 0 {
+		rows = fit(availH, itemH, opts.GutterY)
+	}
+
+	return cols, rows
+}
+
+// gridFits reports whether cols x rows items, with their bleed and the
+// gutters between them, fit in the available area.
+func gridFits(opts ImposeOpts, availW, availH float64, cols, rows int, itemW, itemH float64) bool {
+	if cols == 0 || rows == 0 {
+		return false
+	}
+	usedW := float64(cols)*(itemW+2*opts.Bleed) + float64(cols-1)*opts.GutterX
+	usedH := float64(rows)*(itemH+2*opts.Bleed) + float64(rows-1)*opts.GutterY
+	return usedW <= availW+1e-9 && usedH <= availH+1e-9
+}
+
+// The profile variables Impose sets. An imposition profile run by Impose
+// must declare them and build its imposition fixup from them; see
+// ImposeVariables.
+const (
+	// ImposeVarMode is an ImposeMode
+	ImposeVarMode         = "mode"
+	ImposeVarSheetWidth   = "sheetWidth"
+	ImposeVarSheetHeight  = "sheetHeight"
+	ImposeVarMarginTop    = "marginTop"
+	ImposeVarMarginRight  = "marginRight"
+	ImposeVarMarginBottom = "marginBottom"
+	ImposeVarMarginLeft   = "marginLeft"
+	ImposeVarGutterX      = "gutterX"
+	ImposeVarGutterY      = "gutterY"
+	// ImposeVarColumns and ImposeVarRows are the grid PreviewLayout
+	// computed, never zero
+	ImposeVarColumns = "columns"
+	ImposeVarRows    = "rows"
+	ImposeVarBleed   = "bleed"
+	// ImposeVarOrder is a PageOrder
+	ImposeVarOrder = "order"
+	// ImposeVarRotate is 0, or 90 when the items are turned
+	ImposeVarRotate = "rotate"
+	// ImposeVarMarks is a comma separated list of Marks, empty for none
+	ImposeVarMarks = "marks"
+)
+
+// ImposeVariables returns the names of every variable Impose sets, for
+// checking an imposition profile against them. Lengths are in millimetres.
+func ImposeVariables() []string {
+	return []string{
+		ImposeVarMode, ImposeVarSheetWidth, ImposeVarSheetHeight,
+		ImposeVarMarginTop, ImposeVarMarginRight, ImposeVarMarginBottom, ImposeVarMarginLeft,
+		ImposeVarGutterX, ImposeVarGutterY, ImposeVarColumns, ImposeVarRows, ImposeVarBleed,
+		ImposeVarOrder, ImposeVarRotate, ImposeVarMarks,
+	}
+}
+
+func (o ImposeOpts) args(layout ImposeLayout) []Arg {
+	mode := o.Mode
+	if mode == "" {
+		mode = StepAndRepeat
+	}
+	order := o.Order
+	if order == "" {
+		order = OrderRows
+	}
+	rotate := 0
+	if layout.Rotated {
+		rotate = 90
+	}
+	marks := make([]string, 0, len(o.Marks))
+	for _, m := range o.Marks {
+		marks = append(marks, string(m))
+	}
+
+	return []Arg{
+		NewSetVariableArg(ImposeVarMode, mode),
+		NewSetVariableArg(ImposeVarSheetWidth, o.Sheet.Width),
+		NewSetVariableArg(ImposeVarSheetHeight, o.Sheet.Height),
+		NewSetVariableArg(ImposeVarMarginTop, o.Margins.Top),
+		NewSetVariableArg(ImposeVarMarginRight, o.Margins.Right),
+		NewSetVariableArg(ImposeVarMarginBottom, o.Margins.Bottom),
+		NewSetVariableArg(ImposeVarMarginLeft, o.Margins.Left),
+		NewSetVariableArg(ImposeVarGutterX, o.GutterX),
+		NewSetVariableArg(ImposeVarGutterY, o.GutterY),
+		NewSetVariableArg(ImposeVarColumns, layout.Columns),
+		NewSetVariableArg(ImposeVarRows, layout.Rows),
+		NewSetVariableArg(ImposeVarBleed, o.Bleed),
+		NewSetVariableArg(ImposeVarOrder, order),
+		NewSetVariableArg(ImposeVarRotate, rotate),
+		NewSetVariableArg(ImposeVarMarks, strings.Join(marks, ",")),
+	}
+}
+
+type ImposeResult struct {
+	Layout ImposeLayout
+	Output CmdOutput
+	// Files are the imposed sheets
+	Files []string
+}
+
+// Impose places the pages of inputs on sheets by running the imposition
+// profile in opts with the layout PreviewLayout computes. The layout is
+// passed as --setvariable args named by the ImposeVar constants, so the
+// profile's imposition fixup must read its sheet, margins, gutters, grid,
+// bleed, order, rotation and marks from those variables. Impose does not use
+// pdfToolbox's --impose mode.
+func (cl *Client) Impose(ctx context.Context, inputs []string, output string, opts ImposeOpts) (ImposeResult, error) {
+	if opts.Profile == "" {
+		return ImposeResult{}, errors.New("pdftoolbox: impose needs an imposition profile")
+	}
+
+	layout, err := PreviewLayout(opts)
+	if err != nil {
+		return ImposeResult{}, err
+	}
+
+	out, err := cl.runProfile(ctx, nil, opts.Profile, inputs, append(opts.args(layout), NewOutputFileArg(output))...)
+	if err != nil {
+		return ImposeResult{Layout: layout, Output: out}, err
+	}
+
+	artifacts, err := collectArtifacts(out.Artifacts, "", nil)
+	if err != nil {
+		return ImposeResult{Layout: layout, Output: out}, err
+	}
+	out.Artifacts = artifacts
+
+	files := artifactPaths(artifacts)
+	if len(files) == 0 {
+		files = []string{output}
+	}
+
+	return ImposeResult{Layout: layout, Output: out, Files: files}, nil
+}
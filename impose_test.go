@@ -0,0 +1,164 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewLayoutAutoFit(t *testing.T) {
+	layout, err := pdftoolbox.PreviewLayout(pdftoolbox.ImposeOpts{
+		Sheet:      pdftoolbox.SheetSRA3,
+		Margins:    pdftoolbox.Margins{Top: 10, Right: 10, Bottom: 10, Left: 10},
+		GutterX:    3,
+		GutterY:    3,
+		ItemWidth:  55,
+		ItemHeight: 55,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 5, layout.Columns)
+	assert.Equal(t, 7, layout.Rows)
+	assert.Equal(t, 35, layout.PerSheet)
+	assert.Equal(t, 3, layout.SheetsNeeded(100))
+
+	// 5 columns use 287mm of the 300mm inside the margins, centred
+	first := layout.Cells[0]
+	assert.InDelta(t, 16.5, first.X, 1e-9)
+	assert.InDelta(t, 450-10-(430-403)/2.0-55, first.Y, 1e-9)
+	assert.Equal(t, 1, layout.Cells[1].Column)
+	assert.InDelta(t, 35*55*55/(320*450.0), layout.Utilisation, 1e-9)
+}
+
+func TestPreviewLayoutRotation(t *testing.T) {
+	opts := pdftoolbox.ImposeOpts{
+		Sheet:      pdftoolbox.SheetSize{Width: 100, Height: 60},
+		ItemWidth:  30,
+		ItemHeight: 50,
+		Order:      pdftoolbox.OrderColumns,
+	}
+
+	layout, err := pdftoolbox.PreviewLayout(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, layout.PerSheet)
+	assert.False(t, layout.Rotated)
+
+	opts.AllowRotation = true
+	layout, err = pdftoolbox.PreviewLayout(opts)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, 4, layout.PerSheet)
+	assert.True(t, layout.Rotated)
+	assert.Equal(t, 50.0, layout.Cells[0].Width)
+
+	// Column order fills the first column before moving right
+	assert.Equal(t, 1, layout.Cells[1].Row)
+	assert.Equal(t, 0, layout.Cells[1].Column)
+}
+
+func TestPreviewLayoutRotationFixedGrid(t *testing.T) {
+	// 2 x 1 items of 50 x 30 only fit a 70 x 100 sheet when turned
+	opts := pdftoolbox.ImposeOpts{
+		Sheet:      pdftoolbox.SheetSize{Width: 70, Height: 100},
+		Columns:    2,
+		Rows:       1,
+		ItemWidth:  50,
+		ItemHeight: 30,
+	}
+
+	_, err := pdftoolbox.PreviewLayout(opts)
+	assert.Error(t, err)
+
+	opts.AllowRotation = true
+	layout, err := pdftoolbox.PreviewLayout(opts)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, layout.Rotated)
+	assert.Equal(t, 2, layout.PerSheet)
+	assert.Equal(t, 30.0, layout.Cells[0].Width)
+}
+
+func TestPreviewLayoutErrors(t *testing.T) {
+	_, err := pdftoolbox.PreviewLayout(pdftoolbox.ImposeOpts{Sheet: pdftoolbox.SheetA4, ItemWidth: 300, ItemHeight: 10})
+	assert.Error(t, err)
+
+	_, err = pdftoolbox.PreviewLayout(pdftoolbox.ImposeOpts{Sheet: pdftoolbox.SheetA4, ItemWidth: 55, ItemHeight: 55, Columns: 5})
+	assert.Error(t, err)
+}
+
+func TestImpose(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Output\t/work/sheet.pdf\nDuration\t00:02\n"}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.Impose(context.Background(), []string{"sticker.pdf"}, "/work/sheet.pdf", pdftoolbox.ImposeOpts{
+		Profile:    "/profiles/step-and-repeat.kfpx",
+		Sheet:      pdftoolbox.SheetA4,
+		ItemWidth:  55,
+		ItemHeight: 55,
+		Bleed:      2,
+		Marks:      []pdftoolbox.Mark{pdftoolbox.CropMarks},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 15, res.Layout.PerSheet)
+	assert.Equal(t, []string{"/work/sheet.pdf"}, res.Files)
+	assert.Equal(t, []string{
+		"--setvariable=mode:stepandrepeat",
+		"--setvariable=sheetWidth:210",
+		"--setvariable=sheetHeight:297",
+		"--setvariable=marginTop:0",
+		"--setvariable=marginRight:0",
+		"--setvariable=marginBottom:0",
+		"--setvariable=marginLeft:0",
+		"--setvariable=gutterX:0",
+		"--setvariable=gutterY:0",
+		"--setvariable=columns:3",
+		"--setvariable=rows:5",
+		"--setvariable=bleed:2",
+		"--setvariable=order:rows",
+		"--setvariable=rotate:0",
+		"--setvariable=marks:crop",
+		"--outputfile=/work/sheet.pdf",
+		"/profiles/step-and-repeat.kfpx",
+		"sticker.pdf",
+	}, exe.LastCall())
+
+	// Every variable set is one the profile contract names
+	var set []string
+	for _, a := range exe.LastCall() {
+		if v, ok := strings.CutPrefix(a, "--setvariable="); ok {
+			name, _, _ := strings.Cut(v, ":")
+			set = append(set, name)
+		}
+	}
+	assert.Equal(t, pdftoolbox.ImposeVariables(), set)
+}
+
+func TestImposeNeedsProfile(t *testing.T) {
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: pdftoolboxtest.NewExecutor()})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.Impose(context.Background(), []string{"sticker.pdf"}, "/work/sheet.pdf", pdftoolbox.ImposeOpts{
+		Sheet:      pdftoolbox.SheetA4,
+		ItemWidth:  55,
+		ItemHeight: 55,
+	})
+	assert.ErrorContains(t, err, "imposition profile")
+}
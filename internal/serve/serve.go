@@ -0,0 +1,149 @@
+// Package serve runs the HTTP and gRPC APIs of packages server and
+// grpcserver for the pdftoolbox-server and pdftoolbox-go serve commands.
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/grpcserver"
+	"github.com/fikastudio/pdftoolbox-go/server"
+	"google.golang.org/grpc"
+)
+
+type Opts struct {
+	// Addr is the address HTTP is served on. Defaults to :8080.
+	Addr string
+	// GRPCAddr is the address gRPC is served on. Empty disables gRPC.
+	GRPCAddr      string
+	ProfileFolder string
+	WorkDir       string
+	// Concurrency is the number of jobs both APIs together run at once.
+	// Defaults to 1.
+	Concurrency int
+	// ShutdownTimeout is how long running calls may take to finish once Run
+	// is stopped before they are closed. Defaults to one minute.
+	ShutdownTimeout time.Duration
+	Logger          *slog.Logger
+}
+
+// Run serves cl until ctx is done or either server fails, and returns the
+// failure. Both APIs run their jobs on one pool.
+func Run(ctx context.Context, cl *pdftoolbox.Client, opts *Opts) error {
+	o := Opts{
+		Addr:            ":8080",
+		Concurrency:     1,
+		ShutdownTimeout: time.Minute,
+		Logger:          slog.Default(),
+	}
+	if opts != nil {
+		o.GRPCAddr = opts.GRPCAddr
+		o.ProfileFolder = opts.ProfileFolder
+		o.WorkDir = opts.WorkDir
+		if opts.Addr != "" {
+			o.Addr = opts.Addr
+		}
+		if opts.Concurrency > 0 {
+			o.Concurrency = opts.Concurrency
+		}
+		if opts.ShutdownTimeout > 0 {
+			o.ShutdownTimeout = opts.ShutdownTimeout
+		}
+		if opts.Logger != nil {
+			o.Logger = opts.Logger
+		}
+	}
+	logger := o.Logger
+
+	srv, err := server.New(cl, &server.Opts{
+		ProfileFolder: o.ProfileFolder,
+		WorkDir:       o.WorkDir,
+		Concurrency:   o.Concurrency,
+		Logger:        logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Either server failing stops both
+	errc := make(chan error, 2)
+
+	var grpcServer *grpc.Server
+	if o.GRPCAddr != "" {
+		svc, err := grpcserver.New(cl, &grpcserver.Opts{
+			ProfileFolder: o.ProfileFolder,
+			WorkDir:       o.WorkDir,
+			Pool:          srv.Pool(),
+			Logger:        logger,
+		})
+		if err != nil {
+			srv.Close(context.Background())
+			return err
+		}
+		defer svc.Close(context.Background())
+
+		lis, err := net.Listen("tcp", o.GRPCAddr)
+		if err != nil {
+			srv.Close(context.Background())
+			return err
+		}
+
+		grpcServer = grpc.NewServer()
+		svc.Register(grpcServer)
+		go func() {
+			logger.Info("serving gRPC", "addr", lis.Addr().String())
+			if err := grpcServer.Serve(lis); err != nil {
+				errc <- fmt.Errorf("grpc: %w", err)
+			}
+		}()
+	}
+
+	httpServer := &http.Server{Addr: o.Addr, Handler: srv}
+
+	go func() {
+		logger.Info("serving HTTP", "addr", o.Addr)
+		errc <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), o.ShutdownTimeout)
+	defer cancel()
+
+	if grpcServer != nil {
+		stopGRPC(shutdownCtx, grpcServer)
+	}
+	httpServer.Shutdown(shutdownCtx)
+	srv.Close(shutdownCtx)
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// stopGRPC waits for running calls to finish, or closes them when ctx is done
+// first.
+func stopGRPC(ctx context.Context, gs *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		gs.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		gs.Stop()
+		<-done
+	}
+}
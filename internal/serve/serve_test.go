@@ -0,0 +1,128 @@
+package serve_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/internal/serve"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxpb"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// freeAddr returns a local address nothing listens on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+func newClient(t *testing.T, exe *pdftoolboxtest.Executor) *pdftoolbox.Client {
+	t.Helper()
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, Logger: testLogger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return cl
+}
+
+func TestRunStopsOpenStreams(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n", Delay: time.Minute}
+
+	grpcAddr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serve.Run(ctx, newClient(t, exe), &serve.Opts{
+			Addr:            freeAddr(t),
+			GRPCAddr:        grpcAddr,
+			ProfileFolder:   t.TempDir(),
+			WorkDir:         t.TempDir(),
+			ShutdownTimeout: 100 * time.Millisecond,
+			Logger:          testLogger,
+		})
+	}()
+
+	// Wait for the server before dialling, so the client does not back off
+	assert.Eventually(t, func() bool {
+		c, err := net.Dial("tcp", grpcAddr)
+		if err == nil {
+			c.Close()
+		}
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer conn.Close()
+	c := pdftoolboxpb.NewPDFToolboxClient(conn)
+
+	up, err := c.Upload(context.Background())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	up.Send(&pdftoolboxpb.UploadRequest{Data: &pdftoolboxpb.UploadRequest_Name{Name: "in.pdf"}})
+	up.Send(&pdftoolboxpb.UploadRequest{Data: &pdftoolboxpb.UploadRequest_Chunk{Chunk: []byte("%PDF-1.7")}})
+	resp, err := up.CloseAndRecv()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	stream, err := c.RunProfileStream(context.Background(), &pdftoolboxpb.RunProfileRequest{
+		Profile: "fix.kfpx",
+		FileIds: []string{resp.FileId},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Eventually(t, func() bool { return len(exe.Calls()) > 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// The stream runs for a minute, but shutdown only waits for 100ms
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return")
+	}
+
+	_, err = stream.Recv()
+	assert.Error(t, err)
+}
+
+func TestRunReturnsGRPCListenError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer lis.Close()
+
+	err = serve.Run(context.Background(), newClient(t, pdftoolboxtest.NewExecutor()), &serve.Opts{
+		Addr:          freeAddr(t),
+		GRPCAddr:      lis.Addr().String(),
+		ProfileFolder: t.TempDir(),
+		WorkDir:       t.TempDir(),
+		Logger:        testLogger,
+	})
+	assert.ErrorContains(t, err, "address already in use")
+}
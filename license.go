@@ -0,0 +1,94 @@
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LicenseStatus struct {
+	Product   string
+	Version   string
+	Activated bool
+	// Expires is zero for perpetual licences
+	Expires time.Time
+	// Seats is the number of pdfToolbox processes allowed to run at once
+	Seats int
+	// LicenseServer is the server the seats are taken from, if any
+	LicenseServer string
+	Raw           string
+}
+
+// Expired reports whether the licence has expired at t.
+func (s LicenseStatus) Expired(t time.Time) bool {
+	return !s.Expires.IsZero() && t.After(s.Expires)
+}
+
+func NewLicenseServerArg(addr string) Arg {
+	return Arg{Arg: "--licenseserver", Value: &addr}
+}
+
+// LicenseStatus queries the licence pdfToolbox runs with. A missing licence
+// is reported as a status that is not activated rather than as an error; an
+// answer without an Activated line is an error, as the state is unknown.
+func (cl *Client) LicenseStatus(ctx context.Context) (*LicenseStatus, error) {
+	out, err := cl.runCmd(ctx, "--status")
+	if errors.Is(err, ErrNotActivated) || errors.Is(err, ErrNotSerialized) {
+		var pe *ParsedError
+		errors.As(err, &pe)
+		return &LicenseStatus{Raw: pe.RawOutput}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := &LicenseStatus{Raw: out.Raw}
+	reported := false
+
+	for _, line := range out.Lines {
+		il, ok := line.(CmdOutputIdentityLine)
+		if !ok || len(il.Parts) < 2 {
+			continue
+		}
+
+		value := il.Parts[1]
+		switch il.Parts[0] {
+		case "Product":
+			status.Product = value
+		case "Version":
+			status.Version = value
+		case "Activated":
+			reported = true
+			status.Activated = strings.EqualFold(value, "yes") || strings.EqualFold(value, "true")
+		case "Expires":
+			if t, err := time.Parse(time.DateOnly, value); err == nil {
+				status.Expires = t
+			}
+		case "Seats":
+			status.Seats, _ = strconv.Atoi(value)
+		case "LicenseServer":
+			status.LicenseServer = value
+		}
+	}
+
+	if !reported {
+		return nil, errors.New("pdftoolbox: --status did not report whether pdfToolbox is activated")
+	}
+
+	return status, nil
+}
+
+// Activate activates pdfToolbox on this machine with a licence key.
+func (cl *Client) Activate(ctx context.Context, name, company, key string) error {
+	_, err := cl.runCmd(ctx, "--activate", name, company, key)
+	return err
+}
+
+// Deactivate releases the activation of this machine so the licence can be
+// used elsewhere.
+func (cl *Client) Deactivate(ctx context.Context) error {
+	_, err := cl.runCmd(ctx, "--deactivate")
+	return err
+}
@@ -0,0 +1,83 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLicenseStatus(t *testing.T) {
+	stdout, err := os.ReadFile("testdata/status.txt")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: string(stdout)}
+
+	server := "license.example.com:4711"
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, LicenseServer: &server})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	status, err := cl.LicenseStatus(context.Background())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "callas pdfToolbox CLI", status.Product)
+	assert.Equal(t, "15.1.639", status.Version)
+	assert.True(t, status.Activated)
+	assert.Equal(t, 4, status.Seats)
+	assert.Equal(t, server, status.LicenseServer)
+	assert.False(t, status.Expired(time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, status.Expired(time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Only commands that take a seat go through the licence server
+	assert.Equal(t, []string{"--status"}, exe.Calls()[0])
+
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n"}
+	_, err = cl.RunProfile("profile.kfpx", []string{"in.pdf"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--licenseserver=" + server, "profile.kfpx", "in.pdf"}, exe.LastCall())
+}
+
+func TestLicenseStatusUnknown(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Product\tcallas pdfToolbox CLI\nVersion\t15.1.639\n"}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.LicenseStatus(context.Background())
+	assert.ErrorContains(t, err, "activated")
+}
+
+func TestLicenseStatusNotActivated(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:   "ProcessID\t1\nError\t1008\tNot activated (no license found)\n",
+		ExitCode: 100,
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	status, err := cl.LicenseStatus(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, status.Activated)
+
+	err = cl.Activate(context.Background(), "Jane Doe", "Fika Studio", "KEY-123")
+	assert.ErrorIs(t, err, pdftoolbox.ErrNotActivated)
+	assert.Equal(t, []string{"--activate", "Jane Doe", "Fika Studio", "KEY-123"}, exe.Calls()[1])
+}
@@ -0,0 +1,144 @@
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MergePDF combines inputs, in order, into a single PDF at output and returns
+// the written files.
+func (cl *Client) MergePDF(ctx context.Context, inputs []string, output string) ([]string, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("pdftoolbox: merge needs at least one input")
+	}
+	if err := cl.require(ctx, CapMergeSplit); err != nil {
+		return nil, err
+	}
+
+	cmd := argStrings([]Arg{{Arg: "--mergepdf"}, NewOutputFileArg(output)})
+	cmd = append(cmd, inputs...)
+
+	out, err := cl.runCmd(ctx, cl.seatArgs(cmd)...)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := collectArtifacts(out.Artifacts, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(artifacts) == 0 {
+		if _, err := os.Stat(output); err != nil {
+			return nil, err
+		}
+		return []string{output}, nil
+	}
+
+	return artifactPaths(artifacts), nil
+}
+
+// SplitOpts selects how SplitPDF divides a document. Exactly one of
+// EveryNPages, PageRanges and BookmarkLevel must be set.
+type SplitOpts struct {
+	// EveryNPages writes a document for every N pages
+	EveryNPages int
+	// PageRanges writes a document per range, such as "1-2" or "3,5-7"
+	PageRanges []string
+	// BookmarkLevel splits at the bookmarks of this level, 1 being the top
+	BookmarkLevel int
+	// FileNamePattern names the documents; pdfToolbox appends a counter.
+	FileNamePattern string
+	// OutputFolder receives the documents. When empty a temporary folder is
+	// created, which the caller must remove.
+	OutputFolder string
+}
+
+func (o SplitOpts) args() ([]Arg, error) {
+	args := []Arg{{Arg: "--splitpdf"}}
+
+	modes := 0
+	if o.EveryNPages > 0 {
+		modes++
+		args = append(args, newStringArg("--pages", strconv.Itoa(o.EveryNPages)))
+	}
+	if len(o.PageRanges) > 0 {
+		modes++
+		for _, r := range o.PageRanges {
+			if _, err := expandPageRange(r, 0); err != nil {
+				return nil, err
+			}
+		}
+		args = append(args, newStringArg("--pageranges", strings.Join(o.PageRanges, ";")))
+	}
+	if o.BookmarkLevel > 0 {
+		modes++
+		args = append(args, newStringArg("--bookmarklevel", strconv.Itoa(o.BookmarkLevel)))
+	}
+	if modes != 1 {
+		return nil, errors.New("pdftoolbox: split needs exactly one of EveryNPages, PageRanges or BookmarkLevel")
+	}
+
+	if o.FileNamePattern != "" {
+		args = append(args, NewOutputFileArg(o.FileNamePattern))
+	}
+
+	return args, nil
+}
+
+// SplitPDF splits input into several documents and returns the ones it
+// wrote in document order.
+func (cl *Client) SplitPDF(ctx context.Context, input string, opts SplitOpts) (files []string, err error) {
+	args, err := opts.args()
+	if err != nil {
+		return nil, err
+	}
+	if err := cl.require(ctx, CapMergeSplit); err != nil {
+		return nil, err
+	}
+
+	folder := opts.OutputFolder
+	var before folderSnapshot
+	if folder == "" {
+		if folder, err = os.MkdirTemp("", "pdftoolbox-split-"); err != nil {
+			return nil, err
+		}
+		// The folder is only handed out with the documents in it
+		defer func() {
+			if err != nil {
+				os.RemoveAll(folder)
+			}
+		}()
+	} else if before, err = snapshotFolder(folder); err != nil {
+		return nil, err
+	}
+
+	cmd := argStrings(append(args, NewOutputFolderArg(folder)))
+	cmd = append(cmd, input)
+
+	out, err := cl.runCmd(ctx, cl.seatArgs(cmd)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Output lines come in document order; files only found in the folder
+	// follow in name order. Files that were in the folder before the run are
+	// left out.
+	artifacts, err := collectArtifacts(out.Artifacts, folder, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifactPaths(artifacts), nil
+}
+
+func artifactPaths(artifacts []Artifact) []string {
+	paths := make([]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		paths = append(paths, a.Path)
+	}
+	return paths
+}
@@ -0,0 +1,75 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePDF(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "merged.pdf")
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Output\t" + output + "\nDuration\t00:01\n"}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	files, err := cl.MergePDF(context.Background(), []string{"a.pdf", "b.pdf"}, output)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{output}, files)
+	assert.Equal(t, []string{"--mergepdf", "--outputfile=" + output, "a.pdf", "b.pdf"}, exe.LastCall())
+
+	_, err = cl.MergePDF(context.Background(), nil, output)
+	assert.Error(t, err)
+}
+
+func TestSplitPDF(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "Output\t{outputfolder}/part_2.pdf\nOutput\t{outputfolder}/part_10.pdf\nDuration\t00:01\n",
+		OutputFiles: map[string][]byte{
+			"part_2.pdf":  []byte("%PDF"),
+			"part_10.pdf": []byte("%PDF"),
+		},
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "earlier.pdf"), []byte("%PDF"), 0o644))
+
+	files, err := cl.SplitPDF(context.Background(), "in.pdf", pdftoolbox.SplitOpts{
+		PageRanges:   []string{"1-2", "3,5-7"},
+		OutputFolder: dir,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "part_2.pdf"), filepath.Join(dir, "part_10.pdf")}, files)
+	assert.Equal(t, []string{"--splitpdf", "--pageranges=1-2;3,5-7", "--outputfolder=" + dir, "in.pdf"}, exe.LastCall())
+}
+
+func TestSplitPDFNeedsOneMode(t *testing.T) {
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: pdftoolboxtest.NewExecutor()})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.SplitPDF(context.Background(), "in.pdf", pdftoolbox.SplitOpts{})
+	assert.Error(t, err)
+
+	_, err = cl.SplitPDF(context.Background(), "in.pdf", pdftoolbox.SplitOpts{EveryNPages: 2, BookmarkLevel: 1})
+	assert.Error(t, err)
+
+	_, err = cl.SplitPDF(context.Background(), "in.pdf", pdftoolbox.SplitOpts{PageRanges: []string{"x"}})
+	assert.ErrorContains(t, err, "invalid page range")
+}
@@ -0,0 +1,215 @@
+package pdftoolbox
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// OutputOpts makes RunProfile create a job-scoped output folder, pass it as
+// --outputfolder and collect every file written there into
+// CmdOutput.Artifacts. Runs that already pass NewOutputFolderArg or
+// NewOutputFileArg keep their own output. The folder is emptied before each
+// retry.
+type OutputOpts struct {
+	// Folder is where job folders are created. Empty uses os.TempDir.
+	Folder string
+	// FileName, when set, passes --outputfile with this name inside the job
+	// folder instead of --outputfolder, for profiles that write a single
+	// file.
+	FileName string
+	// Overwrite passes --overwrite so existing files are replaced.
+	Overwrite bool
+	// KeepOnFailure keeps the job folder of failed runs for inspection.
+	KeepOnFailure bool
+}
+
+// Artifact is a file produced by a run. Size and MIMEType are only set for
+// files that exist once the run has finished.
+type Artifact struct {
+	Path     string `json:"path"`
+	Step     string `json:"step,omitempty"`
+	Size     int64  `json:"size"`
+	MIMEType string `json:"mimeType"`
+}
+
+// prepareOutputFolder creates the job folder and adds the output args. It
+// returns an empty folder when output management is off or the caller chose
+// a folder.
+func (cl *Client) prepareOutputFolder(args []Arg) (string, []Arg, error) {
+	if cl.output == nil {
+		return "", args, nil
+	}
+
+	hasFolder, hasOverwrite := false, false
+	for _, a := range args {
+		switch a.Arg {
+		case "--outputfolder", "--outputfile":
+			hasFolder = true
+		case "--overwrite":
+			hasOverwrite = true
+		}
+	}
+
+	args = append([]Arg(nil), args...)
+	if cl.output.Overwrite && !hasOverwrite {
+		args = append(args, NewOverwriteArg())
+	}
+
+	if hasFolder {
+		return "", args, nil
+	}
+
+	dir, err := os.MkdirTemp(cl.output.Folder, "pdftoolbox-job-")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if cl.output.FileName != "" {
+		return dir, append(args, NewOutputFileArg(filepath.Join(dir, cl.output.FileName))), nil
+	}
+	return dir, append(args, NewOutputFolderArg(dir)), nil
+}
+
+// clearFolder removes everything inside dir but keeps dir itself.
+func clearFolder(dir string) error {
+	if dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cl *Client) cleanupOutputFolder(dir string) {
+	if dir == "" || cl.output == nil || cl.output.KeepOnFailure {
+		return
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		cl.logger.Warn("could not remove output folder", "dir", dir, "error", err)
+	}
+}
+
+// folderSnapshot records the files in a folder before a run, so that files
+// the run wrote can be told apart from those that were already there.
+type folderSnapshot map[string]fileStamp
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// snapshotFolder records the files in dir. A missing dir is empty.
+func snapshotFolder(dir string) (folderSnapshot, error) {
+	snap := folderSnapshot{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		snap[path] = fileStamp{fi.Size(), fi.ModTime()}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return snap, nil
+	}
+	return snap, err
+}
+
+// unchanged reports whether path was in the snapshot and has not been
+// written since. A nil snapshot treats every file as new.
+func (s folderSnapshot) unchanged(path string, d fs.DirEntry) bool {
+	before, ok := s[path]
+	if !ok {
+		return false
+	}
+	fi, err := d.Info()
+	if err != nil {
+		return false
+	}
+	return before.size == fi.Size() && before.modTime.Equal(fi.ModTime())
+}
+
+// collectArtifacts fills in size and type of the reported outputs and adds
+// files in dir that pdfToolbox wrote without an Output line. Files recorded
+// in before and not written since are left out.
+func collectArtifacts(reported []Artifact, dir string, before folderSnapshot) ([]Artifact, error) {
+	artifacts := make([]Artifact, 0, len(reported))
+	seen := map[string]bool{}
+
+	add := func(a Artifact) {
+		if seen[filepath.Clean(a.Path)] {
+			return
+		}
+		seen[filepath.Clean(a.Path)] = true
+
+		if fi, err := os.Stat(a.Path); err == nil && !fi.IsDir() {
+			a.Size = fi.Size()
+			a.MIMEType = detectMIMEType(a.Path)
+		}
+		artifacts = append(artifacts, a)
+	}
+
+	for _, a := range reported {
+		add(a)
+	}
+
+	if dir != "" {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && !before.unchanged(path, d) {
+				add(Artifact{Path: path})
+			}
+			return nil
+		})
+		if err != nil {
+			return artifacts, err
+		}
+	}
+
+	if len(artifacts) == 0 {
+		return nil, nil
+	}
+	return artifacts, nil
+}
+
+func detectMIMEType(path string) string {
+	if t := mime.TypeByExtension(filepath.Ext(path)); t != "" {
+		return t
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	b := make([]byte, 512)
+	n, err := io.ReadFull(f, b)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return ""
+	}
+	return http.DetectContentType(b[:n])
+}
@@ -0,0 +1,163 @@
+package pdftoolbox_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunProfileCollectsArtifacts(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "Output\t{outputfolder}/report.pdf\nStep\tCreate PDF copy\nOutput\t{outputfolder}/copy.pdf\nDuration\t00:01\n",
+		OutputFiles: map[string][]byte{
+			"report.pdf": []byte("%PDF-1.7 report"),
+			"copy.pdf":   []byte("%PDF-1.7"),
+			"cutline":    []byte("%PDF-1.7 no extension"),
+		},
+	}
+
+	root := t.TempDir()
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		Output:   &pdftoolbox.OutputOpts{Folder: root, Overwrite: true},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, root, filepath.Dir(res.OutputFolder))
+	assert.Contains(t, exe.Calls()[0], "--overwrite")
+	assert.Contains(t, exe.Calls()[0], "--outputfolder="+res.OutputFolder)
+
+	assert.Equal(t, []pdftoolbox.Artifact{
+		{Path: filepath.Join(res.OutputFolder, "report.pdf"), Size: 15, MIMEType: "application/pdf"},
+		{Path: filepath.Join(res.OutputFolder, "copy.pdf"), Step: "Create PDF copy", Size: 8, MIMEType: "application/pdf"},
+		{Path: filepath.Join(res.OutputFolder, "cutline"), Size: 21, MIMEType: "application/pdf"},
+	}, res.Artifacts)
+}
+
+func TestRunProfileRemovesOutputFolderOnFailure(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:      "Error\t1002\tFile or folder not found\n",
+		ExitCode:    102,
+		OutputFiles: map[string][]byte{"partial.pdf": []byte("%PDF")},
+	}
+
+	root := t.TempDir()
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		Output:   &pdftoolbox.OutputOpts{Folder: root},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	assert.Error(t, err)
+
+	entries, err := os.ReadDir(root)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRunProfileClearsOutputFolderBetweenAttempts(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Enqueue(
+		pdftoolboxtest.Response{
+			Stdout:      "Error\t1009\tLicense server not reachable\n",
+			ExitCode:    100,
+			OutputFiles: map[string][]byte{"partial.pdf": []byte("%PDF")},
+		},
+		pdftoolboxtest.Response{
+			Stdout:      "Output\t{outputfolder}/final.pdf\n",
+			OutputFiles: map[string][]byte{"final.pdf": []byte("%PDF-1.7")},
+		},
+	)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		Output:   &pdftoolbox.OutputOpts{Folder: t.TempDir()},
+		RetryPolicy: &pdftoolbox.RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+			Retryable:      func(*pdftoolbox.ParsedError) bool { return true },
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	if !assert.NoError(t, err) || !assert.Len(t, res.Artifacts, 1) {
+		t.FailNow()
+	}
+	assert.Equal(t, filepath.Join(res.OutputFolder, "final.pdf"), res.Artifacts[0].Path)
+	assert.NoFileExists(t, filepath.Join(res.OutputFolder, "partial.pdf"))
+}
+
+func TestRunProfileRemovesOutputFolderWhenReportIsMissing(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Summary\tErrors\t0\n"}
+
+	root := t.TempDir()
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		Output:   &pdftoolbox.OutputOpts{Folder: root},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.RunProfile("profile.kfpx", []string{"input.pdf"}, pdftoolbox.NewReportArg(pdftoolbox.ReportXML, filepath.Join(t.TempDir(), "report.xml")))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	entries, err := os.ReadDir(root)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRunProfileOutputFileName(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Run: func(args []string) error {
+			for _, a := range args {
+				if path, ok := strings.CutPrefix(a, "--outputfile="); ok {
+					return os.WriteFile(path, []byte("%PDF-1.7"), 0o644)
+				}
+			}
+			return nil
+		},
+		Stdout: "Summary\tErrors\t0\n",
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		Output:   &pdftoolbox.OutputOpts{Folder: t.TempDir(), FileName: "result.pdf"},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	if !assert.NoError(t, err) || !assert.Len(t, res.Artifacts, 1) {
+		t.FailNow()
+	}
+	path := filepath.Join(res.OutputFolder, "result.pdf")
+	assert.Contains(t, exe.LastCall(), "--outputfile="+path)
+	assert.NotContains(t, exe.LastCall(), "--outputfolder="+res.OutputFolder)
+	assert.Equal(t, path, res.Artifacts[0].Path)
+}
@@ -0,0 +1,187 @@
+package pdftoolbox
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OutputParser parses pdfToolbox stdout one line at a time. It is used both
+// by ParseOutput and while streaming output from a running process, so both
+// paths produce the same CmdOutput.
+type OutputParser struct {
+	lines CmdOutputLines
+	out   CmdOutput
+	step  *CmdStepOutput
+}
+
+func NewOutputParser() *OutputParser {
+	return &OutputParser{}
+}
+
+// ParseLine parses a single line without its trailing newline. It returns nil
+// for empty lines, which are not recorded.
+func (p *OutputParser) ParseLine(line string) CmdOutputLine {
+	if len(line) == 0 {
+		return nil
+	}
+
+	items := strings.Split(line, "\t")
+
+	var il CmdOutputIdentityLine
+	il.Line = line
+	il.Parts = items
+
+	var parsed CmdOutputLine = il
+
+	switch items[0] {
+	case "Error", "Errors":
+		var l CmdOutputErrorLine
+
+		l.Code, _ = strconv.ParseInt(field(items, 1), 10, 64)
+		l.Message = field(items, 2)
+
+		parsed = l
+	case "Duration":
+		l := CmdOutputDurationLine{Line: line, Parts: items}
+		l.dur = parseDuration(field(items, 1))
+		p.out.Duration = l.dur
+
+		parsed = l
+	case "ProcessID":
+		l := CmdOutputProcessIDLine{Line: line, Parts: items}
+		l.ProcessID, _ = strconv.Atoi(field(items, 1))
+
+		parsed = l
+	case "Profile":
+		parsed = CmdOutputProfileLine{Line: line, Parts: items, Path: field(items, 1)}
+	case "Input":
+		parsed = CmdOutputInputLine{Line: line, Parts: items, Path: field(items, 1)}
+	case "Pages":
+		l := CmdOutputPagesLine{Line: line, Parts: items}
+		l.Pages, _ = strconv.Atoi(field(items, 1))
+
+		parsed = l
+	case "Progress":
+		l := CmdOutputProgressLine{Line: line, Parts: items}
+		l.Percent, _ = strconv.Atoi(field(items, 1))
+
+		parsed = l
+	case "Variable":
+		// Values may themselves contain tabs
+		value := ""
+		if len(items) > 2 {
+			value = strings.Join(items[2:], "\t")
+		}
+
+		parsed = CmdOutputVariableLine{Line: line, Parts: items, Name: field(items, 1), Value: value}
+	case "Hit":
+		l := CmdOutputHitLine{Line: line, Parts: items, Severity: field(items, 1), Message: field(items, 2)}
+		p.out.Hits = append(p.out.Hits, l)
+		if p.step != nil {
+			p.step.Hits = append(p.step.Hits, l)
+		}
+
+		parsed = l
+	case "Fix":
+		l := CmdOutputFixLine{Line: line, Parts: items, Name: field(items, 1)}
+		p.out.Fixes = append(p.out.Fixes, l)
+		if p.step != nil {
+			p.step.Fixes = append(p.step.Fixes, l)
+		}
+
+		parsed = l
+	case "Summary":
+		l := CmdOutputSummaryLine{Line: line, Parts: items, Kind: field(items, 1)}
+		l.Count, _ = strconv.Atoi(field(items, 2))
+		p.out.Summary.add(l)
+		if p.step != nil {
+			p.step.Summary.add(l)
+		}
+
+		parsed = l
+	case "Finished":
+		parsed = CmdOutputFinishedLine{Line: line, Parts: items, Path: field(items, 1)}
+	case "Dispatcher", "Satellite":
+		l := CmdOutputDispatchLine{Line: line, Parts: items, Role: items[0], Address: field(items, 1), Message: field(items, 2)}
+		if l.Role == "Satellite" && l.Address != "" {
+			p.out.Satellite = l.Address
+		}
+
+		parsed = l
+	case "Step":
+		if p.step != nil {
+			p.out.Steps = append(p.out.Steps, *p.step)
+		}
+
+		p.step = &CmdStepOutput{Name: field(items, 1)}
+	case "Output":
+		if len(items) > 1 {
+			p.out.Artifacts = append(p.out.Artifacts, Artifact{Path: items[1], Step: p.StepName()})
+		}
+		if p.step != nil && len(items) > 1 {
+			p.step.Lines = append(p.step.Lines, il)
+			p.step.OutputFilePaths = append(p.step.OutputFilePaths, items[1])
+		}
+	}
+
+	p.lines = append(p.lines, parsed)
+
+	return parsed
+}
+
+// StepName returns the name of the step currently being parsed, or an empty
+// string before the first Step line.
+func (p *OutputParser) StepName() string {
+	if p.step == nil {
+		return ""
+	}
+	return p.step.Name
+}
+
+// Result returns the output parsed so far. Raw is left for the caller to set.
+func (p *OutputParser) Result() CmdOutput {
+	out := p.out
+	out.Lines = append(CmdOutputLines(nil), p.lines...)
+	out.Steps = append([]CmdStepOutput(nil), p.out.Steps...)
+	out.Hits = append([]CmdOutputHitLine(nil), p.out.Hits...)
+	out.Fixes = append([]CmdOutputFixLine(nil), p.out.Fixes...)
+	out.Artifacts = append([]Artifact(nil), p.out.Artifacts...)
+
+	if p.step != nil {
+		out.Steps = append(out.Steps, *p.step)
+	}
+
+	return out
+}
+
+func ParseOutput(s string) (CmdOutput, error) {
+	p := NewOutputParser()
+
+	for _, line := range strings.Split(s, "\n") {
+		p.ParseLine(line)
+	}
+
+	cmdOutput := p.Result()
+	cmdOutput.Raw = s
+
+	return cmdOutput, nil
+}
+
+// field returns the i-th tab separated field of a line, or an empty string if
+// the line is shorter.
+func field(items []string, i int) string {
+	if i < len(items) {
+		return items[i]
+	}
+	return ""
+}
+
+// parseDuration parses the mm:ss format of Duration lines.
+func parseDuration(s string) time.Duration {
+	t, err := time.Parse("04:05", s)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
@@ -1,30 +1,39 @@
 package pdftoolbox
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
-	"strconv"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
 type PDFToolboxClient interface {
 	RunProfile(profile string, inputFiles []string, args ...Arg) (CmdOutput, error)
+	RunProfileContext(ctx context.Context, profile string, inputFiles []string, args ...Arg) (CmdOutput, error)
+	RunProfileStream(ctx context.Context, profile string, inputFiles []string, onEvent EventHandler, args ...Arg) (CmdOutput, error)
 	EnumerateProfiles(profileFolder string) (*EnumerateProfilesResponse, error)
+	EnumerateProfilesContext(ctx context.Context, profileFolder string) (*EnumerateProfilesResponse, error)
 }
 
 type PDFToolboxExecutor interface {
-	Command(name string, arg ...string) *exec.Cmd
+	CommandContext(ctx context.Context, name string, arg ...string) *exec.Cmd
 	CombinedOutput(cmd *exec.Cmd) ([]byte, error)
 	ExitCode(cmd *exec.Cmd) int
 }
 
+// waitDelay bounds how long Wait blocks on output pipes after the process
+// has been killed.
+const waitDelay = 5 * time.Second
+
 type Executor struct {
 }
 
@@ -33,9 +42,17 @@ func NewExecutor() (*Executor, error) {
 }
 
 func (e Executor) Command(name string, args ...string) *exec.Cmd {
-	cmd := exec.Command(name, args...)
-	return cmd
+	return e.CommandContext(context.Background(), name, args...)
+}
+
+// CommandContext starts pdfToolbox in its own process group so that
+// cancelling ctx kills the tool together with any helper processes it spawned.
+func (e Executor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	setProcessGroup(cmd)
+	cmd.WaitDelay = waitDelay
 
+	return cmd
 }
 
 func (e Executor) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
@@ -52,6 +69,20 @@ type Client struct {
 	cacheFolder   *string
 	profileFolder *string
 	logger        *slog.Logger
+	retryPolicy   *RetryPolicy
+	output        *OutputOpts
+	licenseServer *string
+	dist          *DistOpts
+	validateVars  bool
+
+	capabilities map[Capability]Version
+	versionMu    sync.Mutex
+	version      *Version
+	versionErr   error
+	versionErrAt time.Time
+
+	catalogsMu sync.Mutex
+	catalogs   map[string]*ProfileCatalog
 }
 
 var _ PDFToolboxClient = &Client{}
@@ -61,6 +92,21 @@ type ClientOpts struct {
 	ProfileFolder *string
 	Executor      PDFToolboxExecutor
 	Logger        *slog.Logger
+	// RetryPolicy retries profile runs that fail with transient errors
+	RetryPolicy *RetryPolicy
+	// Output makes RunProfile create an output folder for each run
+	Output *OutputOpts
+	// LicenseServer is the address of the licence server seats are taken
+	// from, passed to profile runs and the other commands that take a seat
+	LicenseServer *string
+	// Dist submits every profile run through a dispatcher
+	Dist *DistOpts
+	// ValidateVariables makes RunProfile check --setvariable args against
+	// the variables the profile declares before running it
+	ValidateVariables bool
+	// Capabilities overrides the first pdfToolbox version assumed to
+	// support a capability
+	Capabilities map[Capability]Version
 }
 
 func New(exePath string, opts *ClientOpts) (*Client, error) {
@@ -75,8 +121,9 @@ func New(exePath string, opts *ClientOpts) (*Client, error) {
 	}
 
 	cl := &Client{
-		executor: exe,
-		exePath:  absPath,
+		executor:     exe,
+		exePath:      absPath,
+		capabilities: maps.Clone(capabilities),
 		logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 			Level: slog.LevelDebug,
 		})),
@@ -95,6 +142,22 @@ func New(exePath string, opts *ClientOpts) (*Client, error) {
 		if opts.Logger != nil {
 			cl.logger = opts.Logger
 		}
+		if opts.RetryPolicy != nil {
+			cl.retryPolicy = opts.RetryPolicy
+		}
+		if opts.Output != nil {
+			cl.output = opts.Output
+		}
+		if opts.LicenseServer != nil {
+			cl.licenseServer = opts.LicenseServer
+		}
+		if opts.Dist != nil {
+			cl.dist = opts.Dist
+		}
+		cl.validateVars = opts.ValidateVariables
+		for c, v := range opts.Capabilities {
+			cl.capabilities[c] = v
+		}
 	}
 
 	return cl, nil
@@ -135,12 +198,16 @@ func NewOutputFolderArg(dir string) Arg {
 	return Arg{Arg: "--outputfolder", Value: &dir}
 }
 
-func (cl *Client) buildProfileCommand(profile string, inputFiles []string, args ...Arg) []string {
-	cmd := []string{}
+func NewOutputFileArg(path string) Arg {
+	return Arg{Arg: "--outputfile", Value: &path}
+}
 
-	for _, a := range args {
-		cmd = append(cmd, a.ArgString())
-	}
+func NewOverwriteArg() Arg {
+	return Arg{Arg: "--overwrite"}
+}
+
+func (cl *Client) buildProfileCommand(profile string, inputFiles []string, args ...Arg) []string {
+	cmd := argStrings(args)
 
 	if cl.profileFolder != nil && filepath.IsLocal(profile) {
 		cmd = append(cmd, path.Join(*cl.profileFolder, profile))
@@ -155,67 +222,181 @@ func (cl *Client) buildProfileCommand(profile string, inputFiles []string, args
 	return cmd
 }
 
+func argStrings(args []Arg) []string {
+	s := []string{}
+	for _, a := range args {
+		s = append(s, a.ArgString())
+	}
+	return s
+}
+
 // RunProfile uses profile in the form of myprofile.kpfx (though the file extension is not checked for)
 func (cl *Client) RunProfile(profile string, inputFiles []string, args ...Arg) (CmdOutput, error) {
-	cmd := cl.buildProfileCommand(profile, inputFiles, args...)
-	return cl.runCmd(cmd...)
+	return cl.RunProfileContext(context.Background(), profile, inputFiles, args...)
 }
 
-func (cl *Client) runCmd(args ...string) (CmdOutput, error) {
-	startedAt := time.Now()
-	cmd := cl.executor.Command(cl.exePath, args...)
+// RunProfileContext is like RunProfile but stops pdfToolbox when ctx is done.
+// In that case the returned error is a *CancelledError.
+func (cl *Client) RunProfileContext(ctx context.Context, profile string, inputFiles []string, args ...Arg) (CmdOutput, error) {
+	return cl.runProfile(ctx, nil, profile, inputFiles, args...)
+}
 
-	cl.logger.Debug("running command", slog.String("cmd", cmd.String()))
+// RunProfileStream is like RunProfileContext but calls onEvent for every
+// progress, step, hit, fix, variable, output, summary and finished line as
+// pdfToolbox prints it. onEvent is called from a single goroutine. Events are
+// not buffered per attempt: when a RetryPolicy runs the profile again, a
+// RetryEvent separates the events of the failed attempt from the next.
+func (cl *Client) RunProfileStream(ctx context.Context, profile string, inputFiles []string, onEvent EventHandler, args ...Arg) (CmdOutput, error) {
+	return cl.runProfile(ctx, onEvent, profile, inputFiles, args...)
+}
 
-	out, err := cl.executor.CombinedOutput(cmd)
-	if len(out) == 0 || cl.executor.ExitCode(cmd) >= 100 {
-		return CmdOutput{}, NewParsedError(cl.executor.ExitCode(cmd), out)
+func (cl *Client) runProfile(ctx context.Context, onEvent EventHandler, profile string, inputFiles []string, args ...Arg) (CmdOutput, error) {
+	if cl.validateVars {
+		if err := cl.validateVariables(ctx, profile, args); err != nil {
+			return CmdOutput{}, err
+		}
 	}
 
-	elapsedTime := time.Since(startedAt)
-	output, err := ParseOutput(string(out))
+	outputFolder, args, err := cl.prepareOutputFolder(args)
 	if err != nil {
-		return CmdOutput{
-			Raw: string(out),
-		}, err
+		return CmdOutput{}, err
+	}
+
+	if cl.dist != nil && !hasArg(args, distArgs...) {
+		args = append(cl.dist.Args(), args...)
+	}
+
+	if err := cl.requireArgs(ctx, args); err != nil {
+		cl.cleanupOutputFolder(outputFolder)
+		return CmdOutput{}, err
+	}
+
+	cmd := cl.seatArgs(cl.buildProfileCommand(profile, inputFiles, args...))
+
+	attempt := 0
+	output, err := cl.runWithRetry(ctx, onEvent, func(onEvent EventHandler) (CmdOutput, error) {
+		// Partial files of a failed attempt must not end up in the
+		// artifacts of the next one
+		if attempt++; attempt > 1 {
+			if err := clearFolder(outputFolder); err != nil {
+				return CmdOutput{}, err
+			}
+		}
+		return cl.streamCmd(ctx, onEvent, cmd...)
+	})
+	if err != nil {
+		cl.cleanupOutputFolder(outputFolder)
+		return output, err
+	}
+
+	output.OutputFolder = outputFolder
+	if output.Artifacts, err = collectArtifacts(output.Artifacts, outputFolder, nil); err != nil {
+		cl.cleanupOutputFolder(outputFolder)
+		return output, err
+	}
+
+	if req, ok := reportFromArgs(args); ok {
+		if output.Report, err = readReport(req); err != nil {
+			cl.cleanupOutputFolder(outputFolder)
+			return output, err
+		}
 	}
-	output.ExitCode = cl.executor.ExitCode(cmd)
-	output.Duration = elapsedTime
 
 	return output, nil
 }
 
-func (cl *Client) EnumerateProfiles(profileFolder string) (*EnumerateProfilesResponse, error) {
-	tmpFile, err := os.CreateTemp("", "enumprofile")
-	if err != nil {
-		return nil, err
+func (cl *Client) runCmd(ctx context.Context, args ...string) (CmdOutput, error) {
+	return cl.streamCmd(ctx, nil, args...)
+}
+
+// seatArgs prepends --licenseserver to the args of a command that takes a
+// seat. Commands that only query or manage the installation, such as
+// --version, --status and --enumprofiles, run without it.
+func (cl *Client) seatArgs(args []string) []string {
+	if cl.licenseServer == nil || slices.ContainsFunc(args, func(a string) bool {
+		return strings.HasPrefix(a, "--licenseserver=")
+	}) {
+		return args
 	}
-	tmpFile.Close()
-	defer os.Remove(tmpFile.Name())
+	return append([]string{NewLicenseServerArg(*cl.licenseServer).ArgString()}, args...)
+}
 
-	_, err = cl.runCmd(
-		"--format=json",
-		"--enumprofiles",
-		profileFolder,
-		tmpFile.Name(),
-	)
-	if err != nil {
-		return nil, err
+func (cl *Client) streamCmd(ctx context.Context, onEvent EventHandler, args ...string) (CmdOutput, error) {
+	startedAt := time.Now()
+	cmd := cl.executor.CommandContext(ctx, cl.exePath, args...)
+
+	cl.logger.Debug("running command", slog.String("cmd", cmd.String()))
+
+	w := newLineWriter(onEvent)
+
+	var err error
+	if se, ok := cl.executor.(StreamingExecutor); ok {
+		err = se.StreamOutput(cmd, w)
+	} else {
+		var out []byte
+		out, err = cl.executor.CombinedOutput(cmd)
+		w.Write(out)
 	}
+	w.Flush()
 
-	tmpFile, err = os.Open(tmpFile.Name())
-	if err != nil {
+	out := w.raw.Bytes()
+	exitCode := cl.executor.ExitCode(cmd)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return CmdOutput{Raw: string(out)}, &CancelledError{Command: cmd.String(), Err: ctxErr}
+	}
+	if err != nil && exitCode < 0 {
+		// The process never started (or never reported an exit status)
+		return CmdOutput{Raw: string(out)}, err
+	}
+	if len(out) == 0 || exitCode >= 100 {
+		return CmdOutput{}, NewParsedError(exitCode, out)
+	}
+
+	elapsedTime := time.Since(startedAt)
+	output := w.parser.Result()
+	output.Raw = string(out)
+	output.ExitCode = exitCode
+	output.Duration = elapsedTime
+
+	return output, nil
+}
+
+func (cl *Client) EnumerateProfiles(profileFolder string) (*EnumerateProfilesResponse, error) {
+	return cl.EnumerateProfilesContext(context.Background(), profileFolder)
+}
+
+func (cl *Client) EnumerateProfilesContext(ctx context.Context, profileFolder string) (*EnumerateProfilesResponse, error) {
+	if err := cl.require(ctx, CapJSONOutput); err != nil {
 		return nil, err
 	}
 
 	var resp EnumerateProfilesResponse
-	if err = json.NewDecoder(tmpFile).Decode(&resp); err != nil {
+
+	err := cl.runJSONOutput(ctx, "enumprofile", &resp, func(outputFile string) []string {
+		return []string{"--format=json", "--enumprofiles", profileFolder, outputFile}
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &resp, nil
 }
 
+// CancelledError is returned when the context passed to a run is cancelled or
+// its deadline expires before pdfToolbox exits.
+type CancelledError struct {
+	Command string
+	Err     error
+}
+
+func (c *CancelledError) Error() string {
+	return fmt.Sprintf("pdftoolbox: command cancelled: %v", c.Err)
+}
+
+func (c *CancelledError) Unwrap() error {
+	return c.Err
+}
+
 type ParsedError struct {
 	b []byte
 	s string
@@ -227,7 +408,65 @@ type ParsedError struct {
 }
 
 func (p *ParsedError) Error() string {
-	return p.Message
+	if p.Message != "" {
+		return p.Message
+	}
+	if rc, ok := p.ReturnCode(); ok {
+		return fmt.Sprintf("pdftoolbox: %s (exit code %d)", rc, p.ProcessExitCode)
+	}
+	return fmt.Sprintf("pdftoolbox: exit code %d", p.ProcessExitCode)
+}
+
+// ReturnCode returns the exit code as a ReturnCode. ok is false when the
+// exit code is outside the range pdfToolbox uses, e.g. -1 for a process
+// killed by a signal.
+func (p *ParsedError) ReturnCode() (rc ReturnCode, ok bool) {
+	if p.ProcessExitCode < 0 || p.ProcessExitCode > math.MaxUint8 {
+		return 0, false
+	}
+	return ReturnCode(p.ProcessExitCode), true
+}
+
+// Reason returns the code of the Error line, falling back to the error
+// implied by the exit code when no Error line was printed.
+func (p *ParsedError) Reason() ReasonCode {
+	if p.Code != 0 {
+		return ReasonCode(p.Code)
+	}
+	if rc, ok := p.ReturnCode(); ok {
+		return returnReasons[rc]
+	}
+	return 0
+}
+
+// ErrorCode returns the exit code of a failed run, or 0 when the exit code
+// does not report a failure.
+func (p *ParsedError) ErrorCode() ErrorCode {
+	if rc, ok := p.ReturnCode(); ok && !rc.Success() {
+		return ErrorCode(rc)
+	}
+	return 0
+}
+
+// Is reports whether target is the sentinel Error for this failure, e.g.
+// errors.Is(err, ErrNotActivated).
+func (p *ParsedError) Is(target error) bool {
+	e, ok := target.(Error)
+	if !ok {
+		return false
+	}
+	if e.Reason != 0 {
+		return e.Reason == p.Reason()
+	}
+	return e.Code != 0 && e.Code == p.ErrorCode()
+}
+
+// class classifies the failure by its Error line, or else its exit code.
+func (p *ParsedError) class() ErrorClass {
+	if class := newReasonError(p.Reason()).Class(); class != ClassUnknown {
+		return class
+	}
+	return newError(p.ErrorCode()).Class()
 }
 
 func NewParsedError(exitCode int, output []byte) *ParsedError {
@@ -240,7 +479,6 @@ func NewParsedError(exitCode int, output []byte) *ParsedError {
 
 	parsed, err := ParseOutput(string(output))
 	if err != nil {
-		fmt.Println(pe)
 		return pe
 	}
 
@@ -258,83 +496,27 @@ func NewParsedError(exitCode int, output []byte) *ParsedError {
 }
 
 type CmdOutput struct {
-	Lines    []CmdOutputLine
-	Steps    []CmdStepOutput
-	Duration time.Duration
-	Command  string
-	Raw      string
-	ExitCode int
-}
-
-func ParseOutput(s string) (CmdOutput, error) {
-	lines := strings.Split(s, "\n")
-	var outLines []CmdOutputLine
-	var cmdOutput CmdOutput
-
-	var step *CmdStepOutput
-
-	for _, line := range lines {
-		items := strings.Split(line, "\t")
-		fmt.Println(strings.Join(items, "|"), len(items))
-
-		if len(line) == 0 {
-			continue
-		}
-
-		var il CmdOutputIdentityLine
-		il.Line = line
-		il.Parts = items
-
-		switch items[0] {
-		case "Error", "Errors":
-			var l CmdOutputErrorLine
-
-			code, err := strconv.ParseInt(items[1], 10, 64)
-			if err != nil {
-				fmt.Println(err)
-			}
-			l.Code = code
-			l.Message = items[2]
-
-			outLines = append(outLines, l)
-		case "Duration":
-			var l CmdOutputDurationLine
-
-			parsed, err := time.Parse("04:05", items[1])
-			if err != nil {
-				fmt.Println(err)
-			}
-			l.dur = time.Duration(parsed.Minute())*time.Minute + time.Duration(parsed.Second())*time.Second
-			cmdOutput.Duration = l.dur
-
-			outLines = append(outLines, l)
-		case "Step":
-			if step != nil {
-				cmdOutput.Steps = append(cmdOutput.Steps, *step)
-			}
-
-			step = &CmdStepOutput{
-				Name: items[1],
-			}
-
-			outLines = append(outLines, il)
-		case "Output":
-			if step != nil {
-				step.Lines = append(step.Lines, il)
-				step.OutputFilePaths = append(step.OutputFilePaths, items[1])
-			}
-			outLines = append(outLines, il)
-		default:
-			outLines = append(outLines, il)
-		}
-	}
-
-	if step != nil {
-		cmdOutput.Steps = append(cmdOutput.Steps, *step)
-	}
-
-	cmdOutput.Lines = outLines
-	cmdOutput.Raw = s
-
-	return cmdOutput, nil
+	Lines CmdOutputLines
+	Steps []CmdStepOutput
+	// Summary adds up the Summary lines of all steps
+	Summary Summary
+	Hits    []CmdOutputHitLine
+	Fixes   []CmdOutputFixLine
+	// Report is set when a JSON or XML report was requested with NewReportArg
+	Report *Report
+	// OutputFolder is the folder created for the run when ClientOpts.Output
+	// is set
+	OutputFolder string
+	// Artifacts lists every file written by the run
+	Artifacts []Artifact
+	// Attempts lists every run made for this output, more than one when a
+	// RetryPolicy retried a failure
+	Attempts []Attempt
+	// Satellite is the address of the satellite that processed a job
+	// submitted with --dist, empty when it ran locally
+	Satellite string
+	Duration  time.Duration
+	Command   string
+	Raw       string
+	ExitCode  int
 }
@@ -1,11 +1,15 @@
 package pdftoolbox_test
 
 import (
+	"context"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,7 +45,27 @@ Duration	01:07
 	errorLine := parsed.Lines[11].(pdftoolbox.CmdOutputErrorLine)
 	assert.Equal(t, "Trim box is not equal to 70 x 70 mm", errorLine.Message)
 
+	hitLine := parsed.Lines[9].(pdftoolbox.CmdOutputHitLine)
+	assert.Equal(t, "Error", hitLine.Severity)
+	assert.Equal(t, "Trim box is not equal to 70 x 70 mm", hitLine.Message)
+
+	variableLine := parsed.Lines[5].(pdftoolbox.CmdOutputVariableLine)
+	assert.Equal(t, "trimHeight", variableLine.Name)
+	assert.Equal(t, "70", variableLine.Value)
+
+	summaryLine := parsed.Lines[13].(pdftoolbox.CmdOutputSummaryLine)
+	assert.Equal(t, "Errors", summaryLine.Kind)
+	assert.Equal(t, 1, summaryLine.Count)
+
+	assert.Equal(t, 13913, parsed.Lines[0].(pdftoolbox.CmdOutputProcessIDLine).ProcessID)
+	assert.Equal(t, 1, parsed.Lines[3].(pdftoolbox.CmdOutputPagesLine).Pages)
+	assert.Equal(t, 39, parsed.Lines[7].(pdftoolbox.CmdOutputProgressLine).Percent)
+
 	assert.Equal(t, time.Minute+time.Second*7, parsed.Duration)
+
+	assert.Equal(t, pdftoolbox.Summary{Errors: 1}, parsed.Summary)
+	assert.Equal(t, pdftoolbox.VerdictErrors, parsed.Summary.Verdict())
+	assert.Len(t, parsed.Hits, 1)
 }
 
 func TestParseOutput(t *testing.T) {
@@ -280,6 +304,15 @@ Duration	00:03`
 	assert.Equal(t, "/opt/impose/work/output/Output_File.pdf_sheeting_x2_0001.pdf", pdfCopyStep.OutputFilePaths[0])
 	assert.Equal(t, "/opt/impose/work/output/Output_File.pdf_sheeting_x2_0002.pdf", pdfCopyStep.OutputFilePaths[1])
 
+	assert.Equal(t, 247, res.Summary.Corrections)
+	assert.Equal(t, pdftoolbox.VerdictFixed, res.Summary.Verdict())
+	assert.Len(t, res.Fixes, 9)
+
+	cutlineStep := res.Steps[7]
+	assert.Equal(t, pdftoolbox.Summary{Corrections: 64}, cutlineStep.Summary)
+	assert.Len(t, cutlineStep.Fixes, 1)
+	assert.Equal(t, "Extract cutline", cutlineStep.Fixes[0].Name)
+
 	lastStep := res.Steps[15]
 	assert.Equal(t, lastStep.Name, "Rename PDF")
 	assert.Len(t, lastStep.Lines, 1)
@@ -298,7 +331,7 @@ func NewFakeExecutor() (*FakeExecutor, error) {
 	return &FakeExecutor{}, nil
 }
 
-func (e FakeExecutor) Command(name string, args ...string) *exec.Cmd {
+func (e FakeExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
 	return e.cmd
 
 }
@@ -336,3 +369,47 @@ Error	1002	Could not open file /opt/impose/profilesxxx: File or folder not found
 	assert.True(t, ok)
 	assert.Equal(t, 1002, pe.ProcessExitCode)
 }
+
+func TestRunProfileAttachesReport(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	err := os.WriteFile(reportPath, []byte(`{"hits": [{"severity": "Error", "page": 1, "message": "Trim box is not equal to 70 x 70 mm"}]}`), 0o644)
+	assert.NoError(t, err)
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:   "Hit\tError\tTrim box is not equal to 70 x 70 mm\nSummary\tErrors\t1",
+		ExitCode: 3,
+	}
+
+	cli, err := pdftoolbox.New("/tmp/fakepdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+	})
+	assert.NoError(t, err)
+
+	// The JSON report needs the version check, which the fake answers
+	res, err := cli.RunProfile("myprofile", []string{"inputfile.pdf"}, pdftoolbox.NewReportArg(pdftoolbox.ReportJSON, reportPath))
+	if !assert.NoError(t, err) || !assert.NotNil(t, res.Report) {
+		t.FailNow()
+	}
+	assert.Len(t, res.Report.Errors(), 1)
+}
+
+func TestReplayNotActivated(t *testing.T) {
+	ts, err := pdftoolboxtest.LoadTranscripts("testdata/not_activated.json")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	cli, err := pdftoolbox.New("/tmp/fakepdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: pdftoolboxtest.NewReplayExecutor(ts...),
+	})
+	assert.NoError(t, err)
+
+	_, err = cli.RunProfile("something", []string{"nothing"})
+	pe, ok := err.(*pdftoolbox.ParsedError)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	assert.Equal(t, int64(1008), pe.Code)
+	assert.Contains(t, pe.RawOutput, "Not activated (no license")
+}
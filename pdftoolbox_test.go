@@ -1,6 +1,7 @@
 package pdftoolbox
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -56,17 +57,21 @@ func TestGeneratesCommandProfileFolder(t *testing.T) {
 }
 
 func TestX(t *testing.T) {
-	_, err := os.Stat("/home/byron/Downloads/callas_pdfToolboxCLI15_x64_Linux_15-1-639/pdfToolbox")
-	if os.IsNotExist(err) {
-		t.Skip()
+	// Runs against an installed, unactivated pdfToolbox
+	exe := os.Getenv("PDFTOOLBOX_EXE")
+	if exe == "" {
+		t.Skip("PDFTOOLBOX_EXE is not set")
+	}
+	if _, err := os.Stat(exe); err != nil {
+		t.Skipf("pdfToolbox not available: %v", err)
 	}
 
-	cl, err := New("/home/byron/Downloads/callas_pdfToolboxCLI15_x64_Linux_15-1-639/pdfToolbox", nil)
+	cl, err := New(exe, nil)
 	if !assert.NoError(t, err) {
 		t.FailNow()
 	}
 
-	_, err = cl.runCmd("something", "nothing")
+	_, err = cl.runCmd(context.Background(), "something", "nothing")
 	if !assert.Error(t, err) {
 		t.FailNow()
 	}
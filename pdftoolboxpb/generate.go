@@ -0,0 +1,5 @@
+// Package pdftoolboxpb holds the protobuf messages and gRPC stubs generated
+// from proto/pdftoolbox/v1/pdftoolbox.proto.
+package pdftoolboxpb
+
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/fikastudio/pdftoolbox-go --go-grpc_out=.. --go-grpc_opt=module=github.com/fikastudio/pdftoolbox-go pdftoolbox/v1/pdftoolbox.proto
@@ -0,0 +1,1990 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pdftoolbox/v1/pdftoolbox.proto
+
+package pdftoolboxpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	EventType_EVENT_TYPE_PROGRESS    EventType = 1
+	EventType_EVENT_TYPE_STEP        EventType = 2
+	EventType_EVENT_TYPE_HIT         EventType = 3
+	EventType_EVENT_TYPE_FIX         EventType = 4
+	EventType_EVENT_TYPE_VARIABLE    EventType = 5
+	EventType_EVENT_TYPE_OUTPUT      EventType = 6
+	EventType_EVENT_TYPE_SUMMARY     EventType = 7
+	EventType_EVENT_TYPE_FINISHED    EventType = 8
+	EventType_EVENT_TYPE_DISPATCH    EventType = 9
+	// A retry starts the run over; discard the state of the failed attempt.
+	EventType_EVENT_TYPE_RETRY EventType = 10
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0:  "EVENT_TYPE_UNSPECIFIED",
+		1:  "EVENT_TYPE_PROGRESS",
+		2:  "EVENT_TYPE_STEP",
+		3:  "EVENT_TYPE_HIT",
+		4:  "EVENT_TYPE_FIX",
+		5:  "EVENT_TYPE_VARIABLE",
+		6:  "EVENT_TYPE_OUTPUT",
+		7:  "EVENT_TYPE_SUMMARY",
+		8:  "EVENT_TYPE_FINISHED",
+		9:  "EVENT_TYPE_DISPATCH",
+		10: "EVENT_TYPE_RETRY",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_PROGRESS":    1,
+		"EVENT_TYPE_STEP":        2,
+		"EVENT_TYPE_HIT":         3,
+		"EVENT_TYPE_FIX":         4,
+		"EVENT_TYPE_VARIABLE":    5,
+		"EVENT_TYPE_OUTPUT":      6,
+		"EVENT_TYPE_SUMMARY":     7,
+		"EVENT_TYPE_FINISHED":    8,
+		"EVENT_TYPE_DISPATCH":    9,
+		"EVENT_TYPE_RETRY":       10,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pdftoolbox_v1_pdftoolbox_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_pdftoolbox_v1_pdftoolbox_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{0}
+}
+
+type UploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadRequest_Name
+	//	*UploadRequest_Chunk
+	Data          isUploadRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadRequest) GetData() isUploadRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadRequest) GetName() string {
+	if x != nil {
+		if x, ok := x.Data.(*UploadRequest_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+func (x *UploadRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadRequest_Data interface {
+	isUploadRequest_Data()
+}
+
+type UploadRequest_Name struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3,oneof"`
+}
+
+type UploadRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadRequest_Name) isUploadRequest_Data() {}
+
+func (*UploadRequest_Chunk) isUploadRequest_Data() {}
+
+type UploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadResponse) Reset() {
+	*x = UploadResponse{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadResponse) ProtoMessage() {}
+
+func (x *UploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
+func (*UploadResponse) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UploadResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type RunProfileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Profile is the path of the profile relative to the server's profile
+	// folder.
+	Profile        string            `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	FileIds        []string          `protobuf:"bytes,2,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	Variables      map[string]string `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TimeoutSeconds int32             `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Priority orders queued jobs when the server runs them by priority.
+	Priority      int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunProfileRequest) Reset() {
+	*x = RunProfileRequest{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunProfileRequest) ProtoMessage() {}
+
+func (x *RunProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunProfileRequest.ProtoReflect.Descriptor instead.
+func (*RunProfileRequest) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RunProfileRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *RunProfileRequest) GetFileIds() []string {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+func (x *RunProfileRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *RunProfileRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RunProfileRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=pdftoolbox.v1.EventType" json:"type,omitempty"`
+	Step  string                 `protobuf:"bytes,2,opt,name=step,proto3" json:"step,omitempty"`
+	// Progress is set for progress events.
+	Progress int32 `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Hit is set for hit events.
+	Hit *Hit `protobuf:"bytes,4,opt,name=hit,proto3" json:"hit,omitempty"`
+	// Fix is the name of the fixup of fix events.
+	Fix string `protobuf:"bytes,5,opt,name=fix,proto3" json:"fix,omitempty"`
+	// Variable is set for variable events.
+	Variable *Variable `protobuf:"bytes,6,opt,name=variable,proto3" json:"variable,omitempty"`
+	// Line is the tab separated line the event was parsed from.
+	Line string `protobuf:"bytes,7,opt,name=line,proto3" json:"line,omitempty"`
+	// Attempt is the 1-based run the event belongs to.
+	Attempt int32 `protobuf:"varint,8,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// Error is the failure of the previous attempt, for retry events.
+	Error         string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Event) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *Event) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *Event) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Event) GetHit() *Hit {
+	if x != nil {
+		return x.Hit
+	}
+	return nil
+}
+
+func (x *Event) GetFix() string {
+	if x != nil {
+		return x.Fix
+	}
+	return ""
+}
+
+func (x *Event) GetVariable() *Variable {
+	if x != nil {
+		return x.Variable
+	}
+	return nil
+}
+
+func (x *Event) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *Event) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *Event) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RunProfileUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*RunProfileUpdate_Event
+	//	*RunProfileUpdate_Result
+	Update        isRunProfileUpdate_Update `protobuf_oneof:"update"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunProfileUpdate) Reset() {
+	*x = RunProfileUpdate{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunProfileUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunProfileUpdate) ProtoMessage() {}
+
+func (x *RunProfileUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunProfileUpdate.ProtoReflect.Descriptor instead.
+func (*RunProfileUpdate) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RunProfileUpdate) GetUpdate() isRunProfileUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *RunProfileUpdate) GetEvent() *Event {
+	if x != nil {
+		if x, ok := x.Update.(*RunProfileUpdate_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *RunProfileUpdate) GetResult() *RunProfileResponse {
+	if x != nil {
+		if x, ok := x.Update.(*RunProfileUpdate_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isRunProfileUpdate_Update interface {
+	isRunProfileUpdate_Update()
+}
+
+type RunProfileUpdate_Event struct {
+	Event *Event `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type RunProfileUpdate_Result struct {
+	Result *RunProfileResponse `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*RunProfileUpdate_Event) isRunProfileUpdate_Update() {}
+
+func (*RunProfileUpdate_Result) isRunProfileUpdate_Update() {}
+
+type Hit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      string                 `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hit) Reset() {
+	*x = Hit{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hit) ProtoMessage() {}
+
+func (x *Hit) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hit.ProtoReflect.Descriptor instead.
+func (*Hit) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Hit) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Hit) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Summary struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Corrections int32                  `protobuf:"varint,1,opt,name=corrections,proto3" json:"corrections,omitempty"`
+	Errors      int32                  `protobuf:"varint,2,opt,name=errors,proto3" json:"errors,omitempty"`
+	Warnings    int32                  `protobuf:"varint,3,opt,name=warnings,proto3" json:"warnings,omitempty"`
+	Infos       int32                  `protobuf:"varint,4,opt,name=infos,proto3" json:"infos,omitempty"`
+	// Verdict is one of "pass", "fixed", "warnings" or "errors".
+	Verdict       string `protobuf:"bytes,5,opt,name=verdict,proto3" json:"verdict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Summary) GetCorrections() int32 {
+	if x != nil {
+		return x.Corrections
+	}
+	return 0
+}
+
+func (x *Summary) GetErrors() int32 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *Summary) GetWarnings() int32 {
+	if x != nil {
+		return x.Warnings
+	}
+	return 0
+}
+
+func (x *Summary) GetInfos() int32 {
+	if x != nil {
+		return x.Infos
+	}
+	return 0
+}
+
+func (x *Summary) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+type Step struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Summary       *Summary               `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Hits          []*Hit                 `protobuf:"bytes,3,rep,name=hits,proto3" json:"hits,omitempty"`
+	Fixes         []string               `protobuf:"bytes,4,rep,name=fixes,proto3" json:"fixes,omitempty"`
+	OutputFiles   []string               `protobuf:"bytes,5,rep,name=output_files,json=outputFiles,proto3" json:"output_files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Step) Reset() {
+	*x = Step{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Step) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Step) ProtoMessage() {}
+
+func (x *Step) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Step.ProtoReflect.Descriptor instead.
+func (*Step) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Step) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Step) GetSummary() *Summary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *Step) GetHits() []*Hit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *Step) GetFixes() []string {
+	if x != nil {
+		return x.Fixes
+	}
+	return nil
+}
+
+func (x *Step) GetOutputFiles() []string {
+	if x != nil {
+		return x.OutputFiles
+	}
+	return nil
+}
+
+type Artifact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name is the path of the file relative to the job's output folder, as
+	// passed to Download.
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size          int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	MimeType      string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Artifact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artifact) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+type RunProfileResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	JobId      string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	ExitCode   int32                  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Summary    *Summary               `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	Hits       []*Hit                 `protobuf:"bytes,4,rep,name=hits,proto3" json:"hits,omitempty"`
+	Fixes      []string               `protobuf:"bytes,5,rep,name=fixes,proto3" json:"fixes,omitempty"`
+	Steps      []*Step                `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	Artifacts  []*Artifact            `protobuf:"bytes,7,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	DurationMs int64                  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Satellite is the satellite that ran the job when it was dispatched.
+	Satellite     string `protobuf:"bytes,9,opt,name=satellite,proto3" json:"satellite,omitempty"`
+	RawOutput     string `protobuf:"bytes,10,opt,name=raw_output,json=rawOutput,proto3" json:"raw_output,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunProfileResponse) Reset() {
+	*x = RunProfileResponse{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunProfileResponse) ProtoMessage() {}
+
+func (x *RunProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunProfileResponse.ProtoReflect.Descriptor instead.
+func (*RunProfileResponse) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RunProfileResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RunProfileResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *RunProfileResponse) GetSummary() *Summary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *RunProfileResponse) GetHits() []*Hit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *RunProfileResponse) GetFixes() []string {
+	if x != nil {
+		return x.Fixes
+	}
+	return nil
+}
+
+func (x *RunProfileResponse) GetSteps() []*Step {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *RunProfileResponse) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *RunProfileResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *RunProfileResponse) GetSatellite() string {
+	if x != nil {
+		return x.Satellite
+	}
+	return ""
+}
+
+func (x *RunProfileResponse) GetRawOutput() string {
+	if x != nil {
+		return x.RawOutput
+	}
+	return ""
+}
+
+type EnumerateProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnumerateProfilesRequest) Reset() {
+	*x = EnumerateProfilesRequest{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnumerateProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumerateProfilesRequest) ProtoMessage() {}
+
+func (x *EnumerateProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumerateProfilesRequest.ProtoReflect.Descriptor instead.
+func (*EnumerateProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{10}
+}
+
+type Variable struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Label string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Type  string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Value is the default value, formatted as text.
+	Value         string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variable) Reset() {
+	*x = Variable{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variable) ProtoMessage() {}
+
+func (x *Variable) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variable.ProtoReflect.Descriptor instead.
+func (*Variable) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Variable) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Variable) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Variable) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Variable) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Profile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Variables     []*Variable            `protobuf:"bytes,4,rep,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Profile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Profile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Profile) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Profile) GetVariables() []*Variable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type EnumerateProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*Profile             `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnumerateProfilesResponse) Reset() {
+	*x = EnumerateProfilesResponse{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnumerateProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumerateProfilesResponse) ProtoMessage() {}
+
+func (x *EnumerateProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumerateProfilesResponse.ProtoReflect.Descriptor instead.
+func (*EnumerateProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EnumerateProfilesResponse) GetProfiles() []*Profile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+type QuickCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuickCheckRequest) Reset() {
+	*x = QuickCheckRequest{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuickCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuickCheckRequest) ProtoMessage() {}
+
+func (x *QuickCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuickCheckRequest.ProtoReflect.Descriptor instead.
+func (*QuickCheckRequest) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QuickCheckRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *QuickCheckRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type PageBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Left          float64                `protobuf:"fixed64,1,opt,name=left,proto3" json:"left,omitempty"`
+	Bottom        float64                `protobuf:"fixed64,2,opt,name=bottom,proto3" json:"bottom,omitempty"`
+	Right         float64                `protobuf:"fixed64,3,opt,name=right,proto3" json:"right,omitempty"`
+	Top           float64                `protobuf:"fixed64,4,opt,name=top,proto3" json:"top,omitempty"`
+	WidthMm       float64                `protobuf:"fixed64,5,opt,name=width_mm,json=widthMm,proto3" json:"width_mm,omitempty"`
+	HeightMm      float64                `protobuf:"fixed64,6,opt,name=height_mm,json=heightMm,proto3" json:"height_mm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageBox) Reset() {
+	*x = PageBox{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageBox) ProtoMessage() {}
+
+func (x *PageBox) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageBox.ProtoReflect.Descriptor instead.
+func (*PageBox) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PageBox) GetLeft() float64 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *PageBox) GetBottom() float64 {
+	if x != nil {
+		return x.Bottom
+	}
+	return 0
+}
+
+func (x *PageBox) GetRight() float64 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *PageBox) GetTop() float64 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+func (x *PageBox) GetWidthMm() float64 {
+	if x != nil {
+		return x.WidthMm
+	}
+	return 0
+}
+
+func (x *PageBox) GetHeightMm() float64 {
+	if x != nil {
+		return x.HeightMm
+	}
+	return 0
+}
+
+type Page struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Rotation      int32                  `protobuf:"varint,2,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	MediaBox      *PageBox               `protobuf:"bytes,3,opt,name=media_box,json=mediaBox,proto3" json:"media_box,omitempty"`
+	CropBox       *PageBox               `protobuf:"bytes,4,opt,name=crop_box,json=cropBox,proto3" json:"crop_box,omitempty"`
+	TrimBox       *PageBox               `protobuf:"bytes,5,opt,name=trim_box,json=trimBox,proto3" json:"trim_box,omitempty"`
+	BleedBox      *PageBox               `protobuf:"bytes,6,opt,name=bleed_box,json=bleedBox,proto3" json:"bleed_box,omitempty"`
+	ArtBox        *PageBox               `protobuf:"bytes,7,opt,name=art_box,json=artBox,proto3" json:"art_box,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Page) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Page) GetRotation() int32 {
+	if x != nil {
+		return x.Rotation
+	}
+	return 0
+}
+
+func (x *Page) GetMediaBox() *PageBox {
+	if x != nil {
+		return x.MediaBox
+	}
+	return nil
+}
+
+func (x *Page) GetCropBox() *PageBox {
+	if x != nil {
+		return x.CropBox
+	}
+	return nil
+}
+
+func (x *Page) GetTrimBox() *PageBox {
+	if x != nil {
+		return x.TrimBox
+	}
+	return nil
+}
+
+func (x *Page) GetBleedBox() *PageBox {
+	if x != nil {
+		return x.BleedBox
+	}
+	return nil
+}
+
+func (x *Page) GetArtBox() *PageBox {
+	if x != nil {
+		return x.ArtBox
+	}
+	return nil
+}
+
+type Font struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Embedded      bool                   `protobuf:"varint,3,opt,name=embedded,proto3" json:"embedded,omitempty"`
+	Subset        bool                   `protobuf:"varint,4,opt,name=subset,proto3" json:"subset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Font) Reset() {
+	*x = Font{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Font) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Font) ProtoMessage() {}
+
+func (x *Font) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Font.ProtoReflect.Descriptor instead.
+func (*Font) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Font) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Font) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Font) GetEmbedded() bool {
+	if x != nil {
+		return x.Embedded
+	}
+	return false
+}
+
+func (x *Font) GetSubset() bool {
+	if x != nil {
+		return x.Subset
+	}
+	return false
+}
+
+type QuickCheckResponse struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	PdfVersion                string                 `protobuf:"bytes,1,opt,name=pdf_version,json=pdfVersion,proto3" json:"pdf_version,omitempty"`
+	Title                     string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Creator                   string                 `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	Producer                  string                 `protobuf:"bytes,4,opt,name=producer,proto3" json:"producer,omitempty"`
+	PageCount                 int32                  `protobuf:"varint,5,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	FileSize                  int64                  `protobuf:"varint,6,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	Encrypted                 bool                   `protobuf:"varint,7,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	Standards                 []string               `protobuf:"bytes,8,rep,name=standards,proto3" json:"standards,omitempty"`
+	Pages                     []*Page                `protobuf:"bytes,9,rep,name=pages,proto3" json:"pages,omitempty"`
+	Fonts                     []*Font                `protobuf:"bytes,10,rep,name=fonts,proto3" json:"fonts,omitempty"`
+	ColorSpaces               []string               `protobuf:"bytes,11,rep,name=color_spaces,json=colorSpaces,proto3" json:"color_spaces,omitempty"`
+	OutputConditionIdentifier string                 `protobuf:"bytes,12,opt,name=output_condition_identifier,json=outputConditionIdentifier,proto3" json:"output_condition_identifier,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *QuickCheckResponse) Reset() {
+	*x = QuickCheckResponse{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuickCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuickCheckResponse) ProtoMessage() {}
+
+func (x *QuickCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuickCheckResponse.ProtoReflect.Descriptor instead.
+func (*QuickCheckResponse) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *QuickCheckResponse) GetPdfVersion() string {
+	if x != nil {
+		return x.PdfVersion
+	}
+	return ""
+}
+
+func (x *QuickCheckResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *QuickCheckResponse) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *QuickCheckResponse) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+func (x *QuickCheckResponse) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (x *QuickCheckResponse) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *QuickCheckResponse) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *QuickCheckResponse) GetStandards() []string {
+	if x != nil {
+		return x.Standards
+	}
+	return nil
+}
+
+func (x *QuickCheckResponse) GetPages() []*Page {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *QuickCheckResponse) GetFonts() []*Font {
+	if x != nil {
+		return x.Fonts
+	}
+	return nil
+}
+
+func (x *QuickCheckResponse) GetColorSpaces() []string {
+	if x != nil {
+		return x.ColorSpaces
+	}
+	return nil
+}
+
+func (x *QuickCheckResponse) GetOutputConditionIdentifier() string {
+	if x != nil {
+		return x.OutputConditionIdentifier
+	}
+	return ""
+}
+
+type DownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadRequest) Reset() {
+	*x = DownloadRequest{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadRequest) ProtoMessage() {}
+
+func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadRequest.ProtoReflect.Descriptor instead.
+func (*DownloadRequest) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DownloadRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *DownloadRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DownloadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadResponse) Reset() {
+	*x = DownloadResponse{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadResponse) ProtoMessage() {}
+
+func (x *DownloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadResponse.ProtoReflect.Descriptor instead.
+func (*DownloadResponse) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DownloadResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type ReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileIds       []string               `protobuf:"bytes,1,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	JobIds        []string               `protobuf:"bytes,2,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRequest) Reset() {
+	*x = ReleaseRequest{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRequest) ProtoMessage() {}
+
+func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReleaseRequest) GetFileIds() []string {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+func (x *ReleaseRequest) GetJobIds() []string {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+type ReleaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseResponse) Reset() {
+	*x = ReleaseResponse{}
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseResponse) ProtoMessage() {}
+
+func (x *ReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP(), []int{22}
+}
+
+var File_pdftoolbox_v1_pdftoolbox_proto protoreflect.FileDescriptor
+
+const file_pdftoolbox_v1_pdftoolbox_proto_rawDesc = "" +
+	"\n" +
+	"\x1epdftoolbox/v1/pdftoolbox.proto\x12\rpdftoolbox.v1\"E\n" +
+	"\rUploadRequest\x12\x14\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"=\n" +
+	"\x0eUploadResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"\x9a\x02\n" +
+	"\x11RunProfileRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x19\n" +
+	"\bfile_ids\x18\x02 \x03(\tR\afileIds\x12M\n" +
+	"\tvariables\x18\x03 \x03(\v2/.pdftoolbox.v1.RunProfileRequest.VariablesEntryR\tvariables\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x96\x02\n" +
+	"\x05Event\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.pdftoolbox.v1.EventTypeR\x04type\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\tR\x04step\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x05R\bprogress\x12$\n" +
+	"\x03hit\x18\x04 \x01(\v2\x12.pdftoolbox.v1.HitR\x03hit\x12\x10\n" +
+	"\x03fix\x18\x05 \x01(\tR\x03fix\x123\n" +
+	"\bvariable\x18\x06 \x01(\v2\x17.pdftoolbox.v1.VariableR\bvariable\x12\x12\n" +
+	"\x04line\x18\a \x01(\tR\x04line\x12\x18\n" +
+	"\aattempt\x18\b \x01(\x05R\aattempt\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\x87\x01\n" +
+	"\x10RunProfileUpdate\x12,\n" +
+	"\x05event\x18\x01 \x01(\v2\x14.pdftoolbox.v1.EventH\x00R\x05event\x12;\n" +
+	"\x06result\x18\x02 \x01(\v2!.pdftoolbox.v1.RunProfileResponseH\x00R\x06resultB\b\n" +
+	"\x06update\";\n" +
+	"\x03Hit\x12\x1a\n" +
+	"\bseverity\x18\x01 \x01(\tR\bseverity\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8f\x01\n" +
+	"\aSummary\x12 \n" +
+	"\vcorrections\x18\x01 \x01(\x05R\vcorrections\x12\x16\n" +
+	"\x06errors\x18\x02 \x01(\x05R\x06errors\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x01(\x05R\bwarnings\x12\x14\n" +
+	"\x05infos\x18\x04 \x01(\x05R\x05infos\x12\x18\n" +
+	"\averdict\x18\x05 \x01(\tR\averdict\"\xad\x01\n" +
+	"\x04Step\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\asummary\x18\x02 \x01(\v2\x16.pdftoolbox.v1.SummaryR\asummary\x12&\n" +
+	"\x04hits\x18\x03 \x03(\v2\x12.pdftoolbox.v1.HitR\x04hits\x12\x14\n" +
+	"\x05fixes\x18\x04 \x03(\tR\x05fixes\x12!\n" +
+	"\foutput_files\x18\x05 \x03(\tR\voutputFiles\"O\n" +
+	"\bArtifact\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\"\xf8\x02\n" +
+	"\x12RunProfileResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x05R\bexitCode\x120\n" +
+	"\asummary\x18\x03 \x01(\v2\x16.pdftoolbox.v1.SummaryR\asummary\x12&\n" +
+	"\x04hits\x18\x04 \x03(\v2\x12.pdftoolbox.v1.HitR\x04hits\x12\x14\n" +
+	"\x05fixes\x18\x05 \x03(\tR\x05fixes\x12)\n" +
+	"\x05steps\x18\x06 \x03(\v2\x13.pdftoolbox.v1.StepR\x05steps\x125\n" +
+	"\tartifacts\x18\a \x03(\v2\x17.pdftoolbox.v1.ArtifactR\tartifacts\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x03R\n" +
+	"durationMs\x12\x1c\n" +
+	"\tsatellite\x18\t \x01(\tR\tsatellite\x12\x1d\n" +
+	"\n" +
+	"raw_output\x18\n" +
+	" \x01(\tR\trawOutput\"\x1a\n" +
+	"\x18EnumerateProfilesRequest\"\\\n" +
+	"\bVariable\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"\x82\x01\n" +
+	"\aProfile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x125\n" +
+	"\tvariables\x18\x04 \x03(\v2\x17.pdftoolbox.v1.VariableR\tvariables\"O\n" +
+	"\x19EnumerateProfilesResponse\x122\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x16.pdftoolbox.v1.ProfileR\bprofiles\"H\n" +
+	"\x11QuickCheckRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x95\x01\n" +
+	"\aPageBox\x12\x12\n" +
+	"\x04left\x18\x01 \x01(\x01R\x04left\x12\x16\n" +
+	"\x06bottom\x18\x02 \x01(\x01R\x06bottom\x12\x14\n" +
+	"\x05right\x18\x03 \x01(\x01R\x05right\x12\x10\n" +
+	"\x03top\x18\x04 \x01(\x01R\x03top\x12\x19\n" +
+	"\bwidth_mm\x18\x05 \x01(\x01R\awidthMm\x12\x1b\n" +
+	"\theight_mm\x18\x06 \x01(\x01R\bheightMm\"\xbb\x02\n" +
+	"\x04Page\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x1a\n" +
+	"\brotation\x18\x02 \x01(\x05R\brotation\x123\n" +
+	"\tmedia_box\x18\x03 \x01(\v2\x16.pdftoolbox.v1.PageBoxR\bmediaBox\x121\n" +
+	"\bcrop_box\x18\x04 \x01(\v2\x16.pdftoolbox.v1.PageBoxR\acropBox\x121\n" +
+	"\btrim_box\x18\x05 \x01(\v2\x16.pdftoolbox.v1.PageBoxR\atrimBox\x123\n" +
+	"\tbleed_box\x18\x06 \x01(\v2\x16.pdftoolbox.v1.PageBoxR\bbleedBox\x12/\n" +
+	"\aart_box\x18\a \x01(\v2\x16.pdftoolbox.v1.PageBoxR\x06artBox\"b\n" +
+	"\x04Font\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bembedded\x18\x03 \x01(\bR\bembedded\x12\x16\n" +
+	"\x06subset\x18\x04 \x01(\bR\x06subset\"\xb2\x03\n" +
+	"\x12QuickCheckResponse\x12\x1f\n" +
+	"\vpdf_version\x18\x01 \x01(\tR\n" +
+	"pdfVersion\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acreator\x18\x03 \x01(\tR\acreator\x12\x1a\n" +
+	"\bproducer\x18\x04 \x01(\tR\bproducer\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x05 \x01(\x05R\tpageCount\x12\x1b\n" +
+	"\tfile_size\x18\x06 \x01(\x03R\bfileSize\x12\x1c\n" +
+	"\tencrypted\x18\a \x01(\bR\tencrypted\x12\x1c\n" +
+	"\tstandards\x18\b \x03(\tR\tstandards\x12)\n" +
+	"\x05pages\x18\t \x03(\v2\x13.pdftoolbox.v1.PageR\x05pages\x12)\n" +
+	"\x05fonts\x18\n" +
+	" \x03(\v2\x13.pdftoolbox.v1.FontR\x05fonts\x12!\n" +
+	"\fcolor_spaces\x18\v \x03(\tR\vcolorSpaces\x12>\n" +
+	"\x1boutput_condition_identifier\x18\f \x01(\tR\x19outputConditionIdentifier\"<\n" +
+	"\x0fDownloadRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"(\n" +
+	"\x10DownloadResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"D\n" +
+	"\x0eReleaseRequest\x12\x19\n" +
+	"\bfile_ids\x18\x01 \x03(\tR\afileIds\x12\x17\n" +
+	"\ajob_ids\x18\x02 \x03(\tR\x06jobIds\"\x11\n" +
+	"\x0fReleaseResponse*\x8d\x02\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13EVENT_TYPE_PROGRESS\x10\x01\x12\x13\n" +
+	"\x0fEVENT_TYPE_STEP\x10\x02\x12\x12\n" +
+	"\x0eEVENT_TYPE_HIT\x10\x03\x12\x12\n" +
+	"\x0eEVENT_TYPE_FIX\x10\x04\x12\x17\n" +
+	"\x13EVENT_TYPE_VARIABLE\x10\x05\x12\x15\n" +
+	"\x11EVENT_TYPE_OUTPUT\x10\x06\x12\x16\n" +
+	"\x12EVENT_TYPE_SUMMARY\x10\a\x12\x17\n" +
+	"\x13EVENT_TYPE_FINISHED\x10\b\x12\x17\n" +
+	"\x13EVENT_TYPE_DISPATCH\x10\t\x12\x14\n" +
+	"\x10EVENT_TYPE_RETRY\x10\n" +
+	"2\xd5\x04\n" +
+	"\n" +
+	"PDFToolbox\x12G\n" +
+	"\x06Upload\x12\x1c.pdftoolbox.v1.UploadRequest\x1a\x1d.pdftoolbox.v1.UploadResponse(\x01\x12Q\n" +
+	"\n" +
+	"RunProfile\x12 .pdftoolbox.v1.RunProfileRequest\x1a!.pdftoolbox.v1.RunProfileResponse\x12W\n" +
+	"\x10RunProfileStream\x12 .pdftoolbox.v1.RunProfileRequest\x1a\x1f.pdftoolbox.v1.RunProfileUpdate0\x01\x12f\n" +
+	"\x11EnumerateProfiles\x12'.pdftoolbox.v1.EnumerateProfilesRequest\x1a(.pdftoolbox.v1.EnumerateProfilesResponse\x12Q\n" +
+	"\n" +
+	"QuickCheck\x12 .pdftoolbox.v1.QuickCheckRequest\x1a!.pdftoolbox.v1.QuickCheckResponse\x12M\n" +
+	"\bDownload\x12\x1e.pdftoolbox.v1.DownloadRequest\x1a\x1f.pdftoolbox.v1.DownloadResponse0\x01\x12H\n" +
+	"\aRelease\x12\x1d.pdftoolbox.v1.ReleaseRequest\x1a\x1e.pdftoolbox.v1.ReleaseResponseB?Z=github.com/fikastudio/pdftoolbox-go/pdftoolboxpb;pdftoolboxpbb\x06proto3"
+
+var (
+	file_pdftoolbox_v1_pdftoolbox_proto_rawDescOnce sync.Once
+	file_pdftoolbox_v1_pdftoolbox_proto_rawDescData []byte
+)
+
+func file_pdftoolbox_v1_pdftoolbox_proto_rawDescGZIP() []byte {
+	file_pdftoolbox_v1_pdftoolbox_proto_rawDescOnce.Do(func() {
+		file_pdftoolbox_v1_pdftoolbox_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pdftoolbox_v1_pdftoolbox_proto_rawDesc), len(file_pdftoolbox_v1_pdftoolbox_proto_rawDesc)))
+	})
+	return file_pdftoolbox_v1_pdftoolbox_proto_rawDescData
+}
+
+var file_pdftoolbox_v1_pdftoolbox_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pdftoolbox_v1_pdftoolbox_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_pdftoolbox_v1_pdftoolbox_proto_goTypes = []any{
+	(EventType)(0),                    // 0: pdftoolbox.v1.EventType
+	(*UploadRequest)(nil),             // 1: pdftoolbox.v1.UploadRequest
+	(*UploadResponse)(nil),            // 2: pdftoolbox.v1.UploadResponse
+	(*RunProfileRequest)(nil),         // 3: pdftoolbox.v1.RunProfileRequest
+	(*Event)(nil),                     // 4: pdftoolbox.v1.Event
+	(*RunProfileUpdate)(nil),          // 5: pdftoolbox.v1.RunProfileUpdate
+	(*Hit)(nil),                       // 6: pdftoolbox.v1.Hit
+	(*Summary)(nil),                   // 7: pdftoolbox.v1.Summary
+	(*Step)(nil),                      // 8: pdftoolbox.v1.Step
+	(*Artifact)(nil),                  // 9: pdftoolbox.v1.Artifact
+	(*RunProfileResponse)(nil),        // 10: pdftoolbox.v1.RunProfileResponse
+	(*EnumerateProfilesRequest)(nil),  // 11: pdftoolbox.v1.EnumerateProfilesRequest
+	(*Variable)(nil),                  // 12: pdftoolbox.v1.Variable
+	(*Profile)(nil),                   // 13: pdftoolbox.v1.Profile
+	(*EnumerateProfilesResponse)(nil), // 14: pdftoolbox.v1.EnumerateProfilesResponse
+	(*QuickCheckRequest)(nil),         // 15: pdftoolbox.v1.QuickCheckRequest
+	(*PageBox)(nil),                   // 16: pdftoolbox.v1.PageBox
+	(*Page)(nil),                      // 17: pdftoolbox.v1.Page
+	(*Font)(nil),                      // 18: pdftoolbox.v1.Font
+	(*QuickCheckResponse)(nil),        // 19: pdftoolbox.v1.QuickCheckResponse
+	(*DownloadRequest)(nil),           // 20: pdftoolbox.v1.DownloadRequest
+	(*DownloadResponse)(nil),          // 21: pdftoolbox.v1.DownloadResponse
+	(*ReleaseRequest)(nil),            // 22: pdftoolbox.v1.ReleaseRequest
+	(*ReleaseResponse)(nil),           // 23: pdftoolbox.v1.ReleaseResponse
+	nil,                               // 24: pdftoolbox.v1.RunProfileRequest.VariablesEntry
+}
+var file_pdftoolbox_v1_pdftoolbox_proto_depIdxs = []int32{
+	24, // 0: pdftoolbox.v1.RunProfileRequest.variables:type_name -> pdftoolbox.v1.RunProfileRequest.VariablesEntry
+	0,  // 1: pdftoolbox.v1.Event.type:type_name -> pdftoolbox.v1.EventType
+	6,  // 2: pdftoolbox.v1.Event.hit:type_name -> pdftoolbox.v1.Hit
+	12, // 3: pdftoolbox.v1.Event.variable:type_name -> pdftoolbox.v1.Variable
+	4,  // 4: pdftoolbox.v1.RunProfileUpdate.event:type_name -> pdftoolbox.v1.Event
+	10, // 5: pdftoolbox.v1.RunProfileUpdate.result:type_name -> pdftoolbox.v1.RunProfileResponse
+	7,  // 6: pdftoolbox.v1.Step.summary:type_name -> pdftoolbox.v1.Summary
+	6,  // 7: pdftoolbox.v1.Step.hits:type_name -> pdftoolbox.v1.Hit
+	7,  // 8: pdftoolbox.v1.RunProfileResponse.summary:type_name -> pdftoolbox.v1.Summary
+	6,  // 9: pdftoolbox.v1.RunProfileResponse.hits:type_name -> pdftoolbox.v1.Hit
+	8,  // 10: pdftoolbox.v1.RunProfileResponse.steps:type_name -> pdftoolbox.v1.Step
+	9,  // 11: pdftoolbox.v1.RunProfileResponse.artifacts:type_name -> pdftoolbox.v1.Artifact
+	12, // 12: pdftoolbox.v1.Profile.variables:type_name -> pdftoolbox.v1.Variable
+	13, // 13: pdftoolbox.v1.EnumerateProfilesResponse.profiles:type_name -> pdftoolbox.v1.Profile
+	16, // 14: pdftoolbox.v1.Page.media_box:type_name -> pdftoolbox.v1.PageBox
+	16, // 15: pdftoolbox.v1.Page.crop_box:type_name -> pdftoolbox.v1.PageBox
+	16, // 16: pdftoolbox.v1.Page.trim_box:type_name -> pdftoolbox.v1.PageBox
+	16, // 17: pdftoolbox.v1.Page.bleed_box:type_name -> pdftoolbox.v1.PageBox
+	16, // 18: pdftoolbox.v1.Page.art_box:type_name -> pdftoolbox.v1.PageBox
+	17, // 19: pdftoolbox.v1.QuickCheckResponse.pages:type_name -> pdftoolbox.v1.Page
+	18, // 20: pdftoolbox.v1.QuickCheckResponse.fonts:type_name -> pdftoolbox.v1.Font
+	1,  // 21: pdftoolbox.v1.PDFToolbox.Upload:input_type -> pdftoolbox.v1.UploadRequest
+	3,  // 22: pdftoolbox.v1.PDFToolbox.RunProfile:input_type -> pdftoolbox.v1.RunProfileRequest
+	3,  // 23: pdftoolbox.v1.PDFToolbox.RunProfileStream:input_type -> pdftoolbox.v1.RunProfileRequest
+	11, // 24: pdftoolbox.v1.PDFToolbox.EnumerateProfiles:input_type -> pdftoolbox.v1.EnumerateProfilesRequest
+	15, // 25: pdftoolbox.v1.PDFToolbox.QuickCheck:input_type -> pdftoolbox.v1.QuickCheckRequest
+	20, // 26: pdftoolbox.v1.PDFToolbox.Download:input_type -> pdftoolbox.v1.DownloadRequest
+	22, // 27: pdftoolbox.v1.PDFToolbox.Release:input_type -> pdftoolbox.v1.ReleaseRequest
+	2,  // 28: pdftoolbox.v1.PDFToolbox.Upload:output_type -> pdftoolbox.v1.UploadResponse
+	10, // 29: pdftoolbox.v1.PDFToolbox.RunProfile:output_type -> pdftoolbox.v1.RunProfileResponse
+	5,  // 30: pdftoolbox.v1.PDFToolbox.RunProfileStream:output_type -> pdftoolbox.v1.RunProfileUpdate
+	14, // 31: pdftoolbox.v1.PDFToolbox.EnumerateProfiles:output_type -> pdftoolbox.v1.EnumerateProfilesResponse
+	19, // 32: pdftoolbox.v1.PDFToolbox.QuickCheck:output_type -> pdftoolbox.v1.QuickCheckResponse
+	21, // 33: pdftoolbox.v1.PDFToolbox.Download:output_type -> pdftoolbox.v1.DownloadResponse
+	23, // 34: pdftoolbox.v1.PDFToolbox.Release:output_type -> pdftoolbox.v1.ReleaseResponse
+	28, // [28:35] is the sub-list for method output_type
+	21, // [21:28] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_pdftoolbox_v1_pdftoolbox_proto_init() }
+func file_pdftoolbox_v1_pdftoolbox_proto_init() {
+	if File_pdftoolbox_v1_pdftoolbox_proto != nil {
+		return
+	}
+	file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[0].OneofWrappers = []any{
+		(*UploadRequest_Name)(nil),
+		(*UploadRequest_Chunk)(nil),
+	}
+	file_pdftoolbox_v1_pdftoolbox_proto_msgTypes[4].OneofWrappers = []any{
+		(*RunProfileUpdate_Event)(nil),
+		(*RunProfileUpdate_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pdftoolbox_v1_pdftoolbox_proto_rawDesc), len(file_pdftoolbox_v1_pdftoolbox_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pdftoolbox_v1_pdftoolbox_proto_goTypes,
+		DependencyIndexes: file_pdftoolbox_v1_pdftoolbox_proto_depIdxs,
+		EnumInfos:         file_pdftoolbox_v1_pdftoolbox_proto_enumTypes,
+		MessageInfos:      file_pdftoolbox_v1_pdftoolbox_proto_msgTypes,
+	}.Build()
+	File_pdftoolbox_v1_pdftoolbox_proto = out.File
+	file_pdftoolbox_v1_pdftoolbox_proto_goTypes = nil
+	file_pdftoolbox_v1_pdftoolbox_proto_depIdxs = nil
+}
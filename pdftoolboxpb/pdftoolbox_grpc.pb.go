@@ -0,0 +1,367 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: pdftoolbox/v1/pdftoolbox.proto
+
+package pdftoolboxpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PDFToolbox_Upload_FullMethodName            = "/pdftoolbox.v1.PDFToolbox/Upload"
+	PDFToolbox_RunProfile_FullMethodName        = "/pdftoolbox.v1.PDFToolbox/RunProfile"
+	PDFToolbox_RunProfileStream_FullMethodName  = "/pdftoolbox.v1.PDFToolbox/RunProfileStream"
+	PDFToolbox_EnumerateProfiles_FullMethodName = "/pdftoolbox.v1.PDFToolbox/EnumerateProfiles"
+	PDFToolbox_QuickCheck_FullMethodName        = "/pdftoolbox.v1.PDFToolbox/QuickCheck"
+	PDFToolbox_Download_FullMethodName          = "/pdftoolbox.v1.PDFToolbox/Download"
+	PDFToolbox_Release_FullMethodName           = "/pdftoolbox.v1.PDFToolbox/Release"
+)
+
+// PDFToolboxClient is the client API for PDFToolbox service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PDFToolbox runs jobs on a pdfToolbox installation. Input PDFs are uploaded
+// first and referred to by file ID; output files of a job are downloaded by
+// job ID and name. Uploads and outputs are removed with Release, or after the
+// server's retention period.
+type PDFToolboxClient interface {
+	// Upload streams one input file. The first message carries its name, the
+	// following ones its content.
+	Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadRequest, UploadResponse], error)
+	RunProfile(ctx context.Context, in *RunProfileRequest, opts ...grpc.CallOption) (*RunProfileResponse, error)
+	// RunProfileStream sends progress, step, hit and other events while the
+	// job runs and the result as the last message.
+	RunProfileStream(ctx context.Context, in *RunProfileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RunProfileUpdate], error)
+	EnumerateProfiles(ctx context.Context, in *EnumerateProfilesRequest, opts ...grpc.CallOption) (*EnumerateProfilesResponse, error)
+	QuickCheck(ctx context.Context, in *QuickCheckRequest, opts ...grpc.CallOption) (*QuickCheckResponse, error)
+	Download(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadResponse], error)
+	Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error)
+}
+
+type pDFToolboxClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPDFToolboxClient(cc grpc.ClientConnInterface) PDFToolboxClient {
+	return &pDFToolboxClient{cc}
+}
+
+func (c *pDFToolboxClient) Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadRequest, UploadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PDFToolbox_ServiceDesc.Streams[0], PDFToolbox_Upload_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadRequest, UploadResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PDFToolbox_UploadClient = grpc.ClientStreamingClient[UploadRequest, UploadResponse]
+
+func (c *pDFToolboxClient) RunProfile(ctx context.Context, in *RunProfileRequest, opts ...grpc.CallOption) (*RunProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunProfileResponse)
+	err := c.cc.Invoke(ctx, PDFToolbox_RunProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pDFToolboxClient) RunProfileStream(ctx context.Context, in *RunProfileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RunProfileUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PDFToolbox_ServiceDesc.Streams[1], PDFToolbox_RunProfileStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RunProfileRequest, RunProfileUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PDFToolbox_RunProfileStreamClient = grpc.ServerStreamingClient[RunProfileUpdate]
+
+func (c *pDFToolboxClient) EnumerateProfiles(ctx context.Context, in *EnumerateProfilesRequest, opts ...grpc.CallOption) (*EnumerateProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnumerateProfilesResponse)
+	err := c.cc.Invoke(ctx, PDFToolbox_EnumerateProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pDFToolboxClient) QuickCheck(ctx context.Context, in *QuickCheckRequest, opts ...grpc.CallOption) (*QuickCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuickCheckResponse)
+	err := c.cc.Invoke(ctx, PDFToolbox_QuickCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pDFToolboxClient) Download(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PDFToolbox_ServiceDesc.Streams[2], PDFToolbox_Download_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadRequest, DownloadResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PDFToolbox_DownloadClient = grpc.ServerStreamingClient[DownloadResponse]
+
+func (c *pDFToolboxClient) Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseResponse)
+	err := c.cc.Invoke(ctx, PDFToolbox_Release_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PDFToolboxServer is the server API for PDFToolbox service.
+// All implementations must embed UnimplementedPDFToolboxServer
+// for forward compatibility.
+//
+// PDFToolbox runs jobs on a pdfToolbox installation. Input PDFs are uploaded
+// first and referred to by file ID; output files of a job are downloaded by
+// job ID and name. Uploads and outputs are removed with Release, or after the
+// server's retention period.
+type PDFToolboxServer interface {
+	// Upload streams one input file. The first message carries its name, the
+	// following ones its content.
+	Upload(grpc.ClientStreamingServer[UploadRequest, UploadResponse]) error
+	RunProfile(context.Context, *RunProfileRequest) (*RunProfileResponse, error)
+	// RunProfileStream sends progress, step, hit and other events while the
+	// job runs and the result as the last message.
+	RunProfileStream(*RunProfileRequest, grpc.ServerStreamingServer[RunProfileUpdate]) error
+	EnumerateProfiles(context.Context, *EnumerateProfilesRequest) (*EnumerateProfilesResponse, error)
+	QuickCheck(context.Context, *QuickCheckRequest) (*QuickCheckResponse, error)
+	Download(*DownloadRequest, grpc.ServerStreamingServer[DownloadResponse]) error
+	Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error)
+	mustEmbedUnimplementedPDFToolboxServer()
+}
+
+// UnimplementedPDFToolboxServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPDFToolboxServer struct{}
+
+func (UnimplementedPDFToolboxServer) Upload(grpc.ClientStreamingServer[UploadRequest, UploadResponse]) error {
+	return status.Error(codes.Unimplemented, "method Upload not implemented")
+}
+func (UnimplementedPDFToolboxServer) RunProfile(context.Context, *RunProfileRequest) (*RunProfileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunProfile not implemented")
+}
+func (UnimplementedPDFToolboxServer) RunProfileStream(*RunProfileRequest, grpc.ServerStreamingServer[RunProfileUpdate]) error {
+	return status.Error(codes.Unimplemented, "method RunProfileStream not implemented")
+}
+func (UnimplementedPDFToolboxServer) EnumerateProfiles(context.Context, *EnumerateProfilesRequest) (*EnumerateProfilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnumerateProfiles not implemented")
+}
+func (UnimplementedPDFToolboxServer) QuickCheck(context.Context, *QuickCheckRequest) (*QuickCheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QuickCheck not implemented")
+}
+func (UnimplementedPDFToolboxServer) Download(*DownloadRequest, grpc.ServerStreamingServer[DownloadResponse]) error {
+	return status.Error(codes.Unimplemented, "method Download not implemented")
+}
+func (UnimplementedPDFToolboxServer) Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedPDFToolboxServer) mustEmbedUnimplementedPDFToolboxServer() {}
+func (UnimplementedPDFToolboxServer) testEmbeddedByValue()                    {}
+
+// UnsafePDFToolboxServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PDFToolboxServer will
+// result in compilation errors.
+type UnsafePDFToolboxServer interface {
+	mustEmbedUnimplementedPDFToolboxServer()
+}
+
+func RegisterPDFToolboxServer(s grpc.ServiceRegistrar, srv PDFToolboxServer) {
+	// If the following call panics, it indicates UnimplementedPDFToolboxServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PDFToolbox_ServiceDesc, srv)
+}
+
+func _PDFToolbox_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PDFToolboxServer).Upload(&grpc.GenericServerStream[UploadRequest, UploadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PDFToolbox_UploadServer = grpc.ClientStreamingServer[UploadRequest, UploadResponse]
+
+func _PDFToolbox_RunProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PDFToolboxServer).RunProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PDFToolbox_RunProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDFToolboxServer).RunProfile(ctx, req.(*RunProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PDFToolbox_RunProfileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunProfileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PDFToolboxServer).RunProfileStream(m, &grpc.GenericServerStream[RunProfileRequest, RunProfileUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PDFToolbox_RunProfileStreamServer = grpc.ServerStreamingServer[RunProfileUpdate]
+
+func _PDFToolbox_EnumerateProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnumerateProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PDFToolboxServer).EnumerateProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PDFToolbox_EnumerateProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDFToolboxServer).EnumerateProfiles(ctx, req.(*EnumerateProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PDFToolbox_QuickCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuickCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PDFToolboxServer).QuickCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PDFToolbox_QuickCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDFToolboxServer).QuickCheck(ctx, req.(*QuickCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PDFToolbox_Download_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PDFToolboxServer).Download(m, &grpc.GenericServerStream[DownloadRequest, DownloadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PDFToolbox_DownloadServer = grpc.ServerStreamingServer[DownloadResponse]
+
+func _PDFToolbox_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PDFToolboxServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PDFToolbox_Release_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDFToolboxServer).Release(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PDFToolbox_ServiceDesc is the grpc.ServiceDesc for PDFToolbox service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PDFToolbox_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pdftoolbox.v1.PDFToolbox",
+	HandlerType: (*PDFToolboxServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RunProfile",
+			Handler:    _PDFToolbox_RunProfile_Handler,
+		},
+		{
+			MethodName: "EnumerateProfiles",
+			Handler:    _PDFToolbox_EnumerateProfiles_Handler,
+		},
+		{
+			MethodName: "QuickCheck",
+			Handler:    _PDFToolbox_QuickCheck_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _PDFToolbox_Release_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Upload",
+			Handler:       _PDFToolbox_Upload_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "RunProfileStream",
+			Handler:       _PDFToolbox_RunProfileStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Download",
+			Handler:       _PDFToolbox_Download_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pdftoolbox/v1/pdftoolbox.proto",
+}
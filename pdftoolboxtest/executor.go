@@ -0,0 +1,300 @@
+// Package pdftoolboxtest provides an in-memory pdfToolbox executor for tests,
+// and a recorder that captures real runs as golden transcripts that the fake
+// can replay without a licence.
+package pdftoolboxtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+// OutputFolderPlaceholder is replaced in Response.Stdout by the value of the
+// command's --outputfolder arg, so canned Output lines can point at the
+// files written from Response.OutputFiles.
+const OutputFolderPlaceholder = "{outputfolder}"
+
+// Response is a canned pdfToolbox run.
+type Response struct {
+	Stdout   string
+	ExitCode int
+	// Delay is waited before any output is produced. It is cut short when
+	// the command's context is cancelled.
+	Delay time.Duration
+	// LineDelay is waited between lines when the output is streamed.
+	LineDelay time.Duration
+	// OutputFiles are written to the command's --outputfolder, keyed by
+	// file name.
+	OutputFiles map[string][]byte
+	// Run is called with the command args before output is produced, for
+	// side effects such as writing the file EnumerateProfiles reads.
+	Run func(args []string) error
+}
+
+// Matcher decides whether a rule applies to the args of a command.
+type Matcher func(args []string) bool
+
+// ArgsContain matches commands that have all of the given args.
+func ArgsContain(want ...string) Matcher {
+	return func(args []string) bool {
+		for _, w := range want {
+			found := false
+			for _, a := range args {
+				if a == w {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+type rule struct {
+	match Matcher
+	resp  Response
+}
+
+// DefaultVersion is the version a new Executor reports for --version.
+const DefaultVersion = "15.1.639"
+
+// Executor is a scriptable fake implementing pdftoolbox.PDFToolboxExecutor
+// and pdftoolbox.StreamingExecutor. A lone --version arg is answered with
+// Version when it is set. Other responses are chosen from the queue first,
+// then from the rules, then Default.
+type Executor struct {
+	Default Response
+	Version string
+
+	mu    sync.Mutex
+	queue []Response
+	rules []rule
+	calls [][]string
+	ctxs  map[*exec.Cmd]context.Context
+	exits map[*exec.Cmd]int
+}
+
+var _ pdftoolbox.PDFToolboxExecutor = &Executor{}
+var _ pdftoolbox.StreamingExecutor = &Executor{}
+
+func NewExecutor() *Executor {
+	return &Executor{
+		Version: DefaultVersion,
+		ctxs:    map[*exec.Cmd]context.Context{},
+		exits:   map[*exec.Cmd]int{},
+	}
+}
+
+// Enqueue adds responses that are used once each, in order.
+func (e *Executor) Enqueue(resp ...Response) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.queue = append(e.queue, resp...)
+}
+
+// On adds a response used for every command matching match.
+func (e *Executor) On(match Matcher, resp Response) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rules = append(e.rules, rule{match: match, resp: resp})
+}
+
+// Calls returns the args of every command run so far, without the
+// executable path.
+func (e *Executor) Calls() [][]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([][]string(nil), e.calls...)
+}
+
+// LastCall returns the args of the most recent command, or nil.
+func (e *Executor) LastCall() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.calls) == 0 {
+		return nil
+	}
+	return e.calls[len(e.calls)-1]
+}
+
+func (e *Executor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := &exec.Cmd{
+		Path: name,
+		Args: append([]string{name}, args...),
+	}
+
+	e.mu.Lock()
+	e.ctxs[cmd] = ctx
+	e.mu.Unlock()
+
+	return cmd
+}
+
+func (e *Executor) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	var sb strings.Builder
+	err := e.run(cmd, &sb, false)
+	return []byte(sb.String()), err
+}
+
+func (e *Executor) StreamOutput(cmd *exec.Cmd, w io.Writer) error {
+	return e.run(cmd, w, true)
+}
+
+// ExitCode returns the exit code of a finished command and forgets it, so
+// that long tests do not accumulate state for every command run. Like
+// pdftoolbox.Client, callers must read it once per command.
+func (e *Executor) ExitCode(cmd *exec.Cmd) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.ctxs, cmd)
+	code, ok := e.exits[cmd]
+	if !ok {
+		return -1
+	}
+	delete(e.exits, cmd)
+	return code
+}
+
+func (e *Executor) run(cmd *exec.Cmd, w io.Writer, stream bool) error {
+	args := cmd.Args[1:]
+
+	e.mu.Lock()
+	ctx := e.ctxs[cmd]
+	delete(e.ctxs, cmd)
+	e.calls = append(e.calls, args)
+	resp := e.respond(args)
+	e.mu.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := sleep(ctx, resp.Delay); err != nil {
+		return err
+	}
+
+	if resp.Run != nil {
+		if err := resp.Run(args); err != nil {
+			return err
+		}
+	}
+
+	outputFolder := argValue(args, "--outputfolder")
+	if len(resp.OutputFiles) > 0 {
+		if outputFolder == "" {
+			return fmt.Errorf("pdftoolboxtest: response has output files but the command has no --outputfolder")
+		}
+		if err := os.MkdirAll(outputFolder, 0o755); err != nil {
+			return err
+		}
+		for name, b := range resp.OutputFiles {
+			if err := os.WriteFile(filepath.Join(outputFolder, name), b, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	stdout := strings.ReplaceAll(resp.Stdout, OutputFolderPlaceholder, outputFolder)
+	if !stream || resp.LineDelay == 0 {
+		io.WriteString(w, stdout)
+	} else {
+		lines := strings.SplitAfter(stdout, "\n")
+		for i, line := range lines {
+			if i > 0 {
+				if err := sleep(ctx, resp.LineDelay); err != nil {
+					return err
+				}
+			}
+			io.WriteString(w, line)
+		}
+	}
+
+	e.mu.Lock()
+	e.exits[cmd] = resp.ExitCode
+	e.mu.Unlock()
+
+	if resp.ExitCode != 0 {
+		return fmt.Errorf("exit status %d", resp.ExitCode)
+	}
+	return nil
+}
+
+// respond must be called with e.mu held.
+func (e *Executor) respond(args []string) Response {
+	if e.Version != "" && len(args) == 1 && args[0] == "--version" {
+		return Response{Stdout: "callas pdfToolbox CLI " + e.Version + "\n"}
+	}
+
+	if len(e.queue) > 0 {
+		resp := e.queue[0]
+		e.queue = e.queue[1:]
+		return resp
+	}
+
+	for _, r := range e.rules {
+		if r.match(args) {
+			return r.resp
+		}
+	}
+
+	return e.Default
+}
+
+// EnumerateProfiles returns a response that writes resp as the JSON file
+// pdftoolbox.Client.EnumerateProfiles reads back.
+func EnumerateProfiles(resp *pdftoolbox.EnumerateProfilesResponse) Response {
+	return Response{
+		Stdout: "ProcessID\t1\nDuration\t00:00\n",
+		Run: func(args []string) error {
+			b, err := json.Marshal(resp)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(args[len(args)-1], b, 0o644)
+		},
+	}
+}
+
+// argValue returns the value of a --name=value arg, with the quotes
+// pdftoolbox.Arg adds around values containing spaces removed.
+func argValue(args []string, name string) string {
+	for _, a := range args {
+		if v, ok := strings.CutPrefix(a, name+"="); ok {
+			return strings.Trim(v, `"`)
+		}
+	}
+	return ""
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
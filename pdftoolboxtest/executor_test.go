@@ -0,0 +1,170 @@
+package pdftoolboxtest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutorWritesOutputFiles(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.On(pdftoolboxtest.ArgsContain("input.pdf"), pdftoolboxtest.Response{
+		Stdout:      "Step\tCreate PDF copy\nOutput\t{outputfolder}/out.pdf\nDuration\t00:01\n",
+		OutputFiles: map[string][]byte{"out.pdf": []byte("%PDF-1.7")},
+	})
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dir := t.TempDir()
+	res, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"}, pdftoolbox.NewOutputFolderArg(dir))
+	if !assert.NoError(t, err) || !assert.Len(t, res.Steps, 1) {
+		t.FailNow()
+	}
+
+	outPath := filepath.Join(dir, "out.pdf")
+	assert.Equal(t, []string{outPath}, res.Steps[0].OutputFilePaths)
+	assert.FileExists(t, outPath)
+	assert.Equal(t, [][]string{{"--outputfolder=" + dir, "profile.kfpx", "input.pdf"}}, exe.Calls())
+}
+
+func TestExecutorDelayHonoursContext(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Duration\t00:01\n", Delay: time.Minute}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = cl.RunProfileContext(ctx, "profile.kfpx", []string{"input.pdf"})
+	var ce *pdftoolbox.CancelledError
+	assert.True(t, errors.As(err, &ce))
+}
+
+func TestExecutorEnumerateProfiles(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Enqueue(pdftoolboxtest.EnumerateProfiles(&pdftoolbox.EnumerateProfilesResponse{
+		Profiles: []pdftoolbox.Profiles{{Name: "CLI_Example", Path: "/profiles/CLI_Example.kfpx"}},
+	}))
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	resp, err := cl.EnumerateProfiles("/profiles")
+	if !assert.NoError(t, err) || !assert.Len(t, resp.Profiles, 1) {
+		t.FailNow()
+	}
+	assert.Equal(t, "CLI_Example", resp.Profiles[0].Name)
+}
+
+func TestRecorderReplay(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Progress\t100\t%\nSummary\tErrors\t0\n", ExitCode: 0}
+
+	rec := pdftoolboxtest.NewRecorder(exe)
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: rec})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	recorded, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	assert.NoError(t, err)
+
+	golden := filepath.Join(t.TempDir(), "golden.json")
+	if !assert.NoError(t, rec.Save(golden)) {
+		t.FailNow()
+	}
+
+	ts, err := pdftoolboxtest.LoadTranscripts(golden)
+	if !assert.NoError(t, err) || !assert.Len(t, ts, 1) {
+		t.FailNow()
+	}
+	assert.Equal(t, []string{"profile.kfpx", "input.pdf"}, ts[0].Args)
+
+	cl, err = pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: pdftoolboxtest.NewReplayExecutor(ts...)})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	replayed, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	assert.NoError(t, err)
+	assert.Equal(t, recorded.Lines, replayed.Lines)
+	assert.Equal(t, recorded.Raw, replayed.Raw)
+
+	_, err = os.Stat(golden)
+	assert.NoError(t, err)
+}
+
+func TestRecorderReplayVersionRun(t *testing.T) {
+	// A JSON report makes RunProfile detect the version first, so the
+	// recording holds a --version run before the profile run
+	report, err := os.ReadFile("../testdata/report.json")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	if !assert.NoError(t, os.WriteFile(reportPath, report, 0o644)) {
+		t.FailNow()
+	}
+	args := []pdftoolbox.Arg{
+		pdftoolbox.NewSetVariableArg("trimWidth", 210),
+		pdftoolbox.NewReportArg(pdftoolbox.ReportJSON, reportPath),
+	}
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Progress\t100\t%\nSummary\tErrors\t0\n"}
+
+	rec := pdftoolboxtest.NewRecorder(exe)
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: rec})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	recorded, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"}, args...)
+	assert.NoError(t, err)
+
+	ts := rec.Transcripts()
+	if !assert.Len(t, ts, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, []string{"--version"}, ts[0].Args)
+
+	replay := pdftoolboxtest.NewReplayExecutor(ts...)
+	cl, err = pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: replay})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	replayed, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"}, args...)
+	assert.NoError(t, err)
+	assert.Equal(t, recorded.Raw, replayed.Raw)
+	assert.Equal(t, recorded.Lines, replayed.Lines)
+	assert.Len(t, replay.Calls(), 2)
+}
+
+func TestExecutorForgetsFinishedCommands(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Error\t1006\tDamaged\n", ExitCode: 105}
+
+	cmd := exe.CommandContext(context.Background(), "/tmp/pdftoolbox", "profile.kfpx", "in.pdf")
+	_, err := exe.CombinedOutput(cmd)
+	assert.Error(t, err)
+
+	assert.Equal(t, 105, exe.ExitCode(cmd))
+	assert.Equal(t, -1, exe.ExitCode(cmd))
+}
@@ -0,0 +1,135 @@
+package pdftoolboxtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+// Transcript is a recorded pdfToolbox run.
+type Transcript struct {
+	Args     []string      `json:"args"`
+	Stdout   string        `json:"stdout"`
+	ExitCode int           `json:"exitCode"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Recorder wraps a real executor and records every run it makes. Only
+// stdout and the exit code are captured, not files written by the tool.
+type Recorder struct {
+	exe pdftoolbox.PDFToolboxExecutor
+
+	mu          sync.Mutex
+	transcripts []Transcript
+	exits       map[*exec.Cmd]int
+}
+
+var _ pdftoolbox.PDFToolboxExecutor = &Recorder{}
+var _ pdftoolbox.StreamingExecutor = &Recorder{}
+
+func NewRecorder(exe pdftoolbox.PDFToolboxExecutor) *Recorder {
+	return &Recorder{exe: exe, exits: map[*exec.Cmd]int{}}
+}
+
+func (r *Recorder) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return r.exe.CommandContext(ctx, name, args...)
+}
+
+func (r *Recorder) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	startedAt := time.Now()
+	out, err := r.exe.CombinedOutput(cmd)
+	r.record(cmd, out, time.Since(startedAt))
+	return out, err
+}
+
+func (r *Recorder) StreamOutput(cmd *exec.Cmd, w io.Writer) error {
+	se, ok := r.exe.(pdftoolbox.StreamingExecutor)
+	if !ok {
+		out, err := r.CombinedOutput(cmd)
+		w.Write(out)
+		return err
+	}
+
+	var buf bytes.Buffer
+	startedAt := time.Now()
+	err := se.StreamOutput(cmd, io.MultiWriter(w, &buf))
+	r.record(cmd, buf.Bytes(), time.Since(startedAt))
+	return err
+}
+
+// ExitCode returns the exit code recorded for cmd and forgets it.
+func (r *Recorder) ExitCode(cmd *exec.Cmd) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	code, ok := r.exits[cmd]
+	if !ok {
+		return r.exe.ExitCode(cmd)
+	}
+	delete(r.exits, cmd)
+	return code
+}
+
+func (r *Recorder) record(cmd *exec.Cmd, out []byte, dur time.Duration) {
+	code := r.exe.ExitCode(cmd)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exits[cmd] = code
+	r.transcripts = append(r.transcripts, Transcript{
+		Args:     cmd.Args[1:],
+		Stdout:   string(out),
+		ExitCode: code,
+		Duration: dur,
+	})
+}
+
+func (r *Recorder) Transcripts() []Transcript {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Transcript(nil), r.transcripts...)
+}
+
+// Save writes the recorded transcripts to path as a golden file.
+func (r *Recorder) Save(path string) error {
+	b, err := json.MarshalIndent(r.Transcripts(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+func LoadTranscripts(path string) ([]Transcript, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ts []Transcript
+	if err := json.Unmarshal(b, &ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// NewReplayExecutor returns a fake that answers commands with the recorded
+// transcripts, in order. Args are not compared since they often contain
+// temporary paths; use Calls to check them. Version is cleared so that a
+// recorded --version run is replayed from the queue like any other.
+func NewReplayExecutor(ts ...Transcript) *Executor {
+	e := NewExecutor()
+	e.Version = ""
+	for _, t := range ts {
+		e.Enqueue(Response{Stdout: t.Stdout, ExitCode: t.ExitCode})
+	}
+	return e
+}
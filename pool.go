@@ -0,0 +1,360 @@
+package pdftoolbox
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrPoolClosed = errors.New("pdftoolbox: pool is closed")
+
+type Ordering int
+
+const (
+	// FIFO runs jobs in the order they were submitted.
+	FIFO Ordering = iota
+	// PriorityOrder runs jobs with a higher Job.Priority first, and jobs of
+	// equal priority in submission order.
+	PriorityOrder
+)
+
+type PoolOpts struct {
+	// Concurrency is the maximum number of pdfToolbox processes running at
+	// once, usually the number of seats of the licence. Defaults to 1.
+	Concurrency int
+	Ordering    Ordering
+}
+
+type Job struct {
+	Profile    string
+	InputFiles []string
+	Args       []Arg
+	// Priority is only used with PriorityOrder; higher runs first.
+	Priority int
+	// OnEvent receives streamed output events while the job runs.
+	OnEvent EventHandler
+	// Run, when set, is called instead of running Profile, so that other
+	// pdfToolbox commands such as QuickCheck share the pool's seats.
+	Run func(ctx context.Context) (CmdOutput, error)
+	// OnStart is called when a worker takes the job from the queue.
+	OnStart func()
+}
+
+type JobResult struct {
+	Output CmdOutput
+	Err    error
+	// Waited is how long the job spent queued before it started.
+	Waited time.Duration
+}
+
+// Future is the pending result of a submitted job.
+type Future struct {
+	done   chan struct{}
+	result JobResult
+}
+
+// Done is closed once the job has finished.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the job has finished or ctx is done. Cancelling ctx does
+// not cancel the job itself; use the context passed to Submit for that.
+func (f *Future) Wait(ctx context.Context) (CmdOutput, error) {
+	select {
+	case <-f.done:
+		return f.result.Output, f.result.Err
+	case <-ctx.Done():
+		return CmdOutput{}, ctx.Err()
+	}
+}
+
+// Result returns the result of a finished job. It must only be called after
+// Done is closed.
+func (f *Future) Result() JobResult {
+	return f.result
+}
+
+type PoolMetrics struct {
+	QueueDepth int
+	Running    int
+	Completed  uint64
+	Failed     uint64
+	TotalWait  time.Duration
+	MaxWait    time.Duration
+}
+
+// AverageWait is the mean time finished and running jobs spent queued.
+func (m PoolMetrics) AverageWait() time.Duration {
+	started := m.Completed + m.Failed + uint64(m.Running)
+	if started == 0 {
+		return 0
+	}
+	return m.TotalWait / time.Duration(started)
+}
+
+// Pool runs profiles through a client with a bounded number of concurrent
+// pdfToolbox processes.
+type Pool struct {
+	client PDFToolboxClient
+	opts   PoolOpts
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   jobQueue
+	seq     uint64
+	closed  bool
+	metrics PoolMetrics
+
+	// ctx is cancelled when Shutdown gives up waiting, to kill running jobs
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPool(client PDFToolboxClient, opts *PoolOpts) *Pool {
+	p := &Pool{
+		client: client,
+		opts:   PoolOpts{Concurrency: 1},
+	}
+
+	if opts != nil {
+		if opts.Concurrency > 0 {
+			p.opts.Concurrency = opts.Concurrency
+		}
+		p.opts.Ordering = opts.Ordering
+	}
+
+	p.cond = sync.NewCond(&p.mu)
+	p.queue.ordering = p.opts.Ordering
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+
+	for range p.opts.Concurrency {
+		p.wg.Add(1)
+		go p.worker()
+	}
+
+	return p
+}
+
+// Submit queues a job. ctx bounds the whole job, including the time it
+// spends queued.
+func (p *Pool) Submit(ctx context.Context, job Job) (*Future, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	f := &Future{done: make(chan struct{})}
+
+	p.seq++
+	qj := &queuedJob{
+		ctx:      ctx,
+		job:      job,
+		future:   f,
+		seq:      p.seq,
+		queuedAt: time.Now(),
+	}
+	heap.Push(&p.queue, qj)
+	p.metrics.QueueDepth = p.queue.Len()
+	p.cond.Signal()
+
+	// Jobs cancelled while queued leave the queue at once instead of
+	// waiting for a worker to pop them
+	qj.stop = context.AfterFunc(ctx, func() { p.dequeue(qj) })
+
+	return f, nil
+}
+
+// dequeue removes a job whose context is done from the queue and fails its
+// future. Jobs a worker already took are left alone.
+func (p *Pool) dequeue(qj *queuedJob) {
+	p.mu.Lock()
+	if qj.index < 0 {
+		p.mu.Unlock()
+		return
+	}
+	heap.Remove(&p.queue, qj.index)
+	p.metrics.QueueDepth = p.queue.Len()
+	p.metrics.Failed++
+	p.mu.Unlock()
+
+	qj.future.result = JobResult{Err: qj.ctx.Err(), Waited: time.Since(qj.queuedAt)}
+	close(qj.future.done)
+}
+
+// Do runs fn as a job on the pool and waits for it to finish. ctx bounds
+// both the wait in the queue and fn.
+func (p *Pool) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	f, err := p.Submit(ctx, Job{Run: func(ctx context.Context) (CmdOutput, error) {
+		return CmdOutput{}, fn(ctx)
+	}})
+	if err != nil {
+		return err
+	}
+
+	<-f.Done()
+	return f.Result().Err
+}
+
+// Metrics returns a snapshot of the pool counters.
+func (p *Pool) Metrics() PoolMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.metrics
+}
+
+// Shutdown stops accepting jobs and waits for queued and running jobs to
+// finish. If ctx is done first, running jobs are cancelled, queued jobs fail
+// with ErrPoolClosed and ctx.Err() is returned.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	for p.queue.Len() > 0 {
+		qj := heap.Pop(&p.queue).(*queuedJob)
+		qj.stop()
+		qj.future.result = JobResult{Err: ErrPoolClosed}
+		close(qj.future.done)
+	}
+	p.metrics.QueueDepth = 0
+	p.mu.Unlock()
+
+	p.cancel()
+	<-done
+
+	return ctx.Err()
+}
+
+func (p *Pool) worker() {
+	defer p.wg.Done()
+
+	for {
+		p.mu.Lock()
+		for p.queue.Len() == 0 && !p.closed {
+			p.cond.Wait()
+		}
+		if p.queue.Len() == 0 {
+			p.mu.Unlock()
+			return
+		}
+
+		qj := heap.Pop(&p.queue).(*queuedJob)
+		qj.stop()
+		waited := time.Since(qj.queuedAt)
+		p.metrics.QueueDepth = p.queue.Len()
+		p.metrics.Running++
+		p.metrics.TotalWait += waited
+		p.metrics.MaxWait = max(p.metrics.MaxWait, waited)
+		p.mu.Unlock()
+
+		res := p.run(qj)
+		res.Waited = waited
+
+		p.mu.Lock()
+		p.metrics.Running--
+		if res.Err != nil {
+			p.metrics.Failed++
+		} else {
+			p.metrics.Completed++
+		}
+		p.mu.Unlock()
+
+		qj.future.result = res
+		close(qj.future.done)
+	}
+}
+
+func (p *Pool) run(qj *queuedJob) JobResult {
+	if err := qj.ctx.Err(); err != nil {
+		return JobResult{Err: err}
+	}
+
+	ctx, cancel := context.WithCancel(qj.ctx)
+	defer cancel()
+	stop := context.AfterFunc(p.ctx, cancel)
+	defer stop()
+
+	if qj.job.OnStart != nil {
+		qj.job.OnStart()
+	}
+	if qj.job.Run != nil {
+		out, err := qj.job.Run(ctx)
+		return JobResult{Output: out, Err: err}
+	}
+
+	out, err := p.client.RunProfileStream(ctx, qj.job.Profile, qj.job.InputFiles, qj.job.OnEvent, qj.job.Args...)
+	return JobResult{Output: out, Err: err}
+}
+
+type queuedJob struct {
+	ctx      context.Context
+	job      Job
+	future   *Future
+	seq      uint64
+	queuedAt time.Time
+	// index is the position in the heap, or -1 once the job left it
+	index int
+	// stop unregisters the dequeue on cancellation
+	stop func() bool
+}
+
+// jobQueue implements heap.Interface
+type jobQueue struct {
+	ordering Ordering
+	items    []*queuedJob
+}
+
+func (q jobQueue) Len() int {
+	return len(q.items)
+}
+
+func (q jobQueue) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if q.ordering == PriorityOrder && a.job.Priority != b.job.Priority {
+		return a.job.Priority > b.job.Priority
+	}
+	return a.seq < b.seq
+}
+
+func (q jobQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *jobQueue) Push(x any) {
+	qj := x.(*queuedJob)
+	qj.index = len(q.items)
+	q.items = append(q.items, qj)
+}
+
+func (q *jobQueue) Pop() any {
+	n := len(q.items)
+	item := q.items[n-1]
+	item.index = -1
+	q.items[n-1] = nil
+	q.items = q.items[:n-1]
+	return item
+}
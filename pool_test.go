@@ -0,0 +1,188 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	pdftoolbox.PDFToolboxClient
+
+	delay   time.Duration
+	block   chan struct{}
+	running atomic.Int32
+	peak    atomic.Int32
+
+	mu    sync.Mutex
+	order []string
+}
+
+func (c *fakeClient) RunProfileStream(ctx context.Context, profile string, inputFiles []string, onEvent pdftoolbox.EventHandler, args ...pdftoolbox.Arg) (pdftoolbox.CmdOutput, error) {
+	n := c.running.Add(1)
+	defer c.running.Add(-1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	c.order = append(c.order, profile)
+	c.mu.Unlock()
+
+	if c.block != nil && profile == "blocker" {
+		select {
+		case <-c.block:
+		case <-ctx.Done():
+			return pdftoolbox.CmdOutput{}, ctx.Err()
+		}
+	}
+
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return pdftoolbox.CmdOutput{}, ctx.Err()
+	}
+
+	return pdftoolbox.CmdOutput{Raw: profile}, nil
+}
+
+func TestPoolConcurrencyLimit(t *testing.T) {
+	cl := &fakeClient{delay: 20 * time.Millisecond}
+	pool := pdftoolbox.NewPool(cl, &pdftoolbox.PoolOpts{Concurrency: 2})
+
+	var futures []*pdftoolbox.Future
+	for range 6 {
+		f, err := pool.Submit(context.Background(), pdftoolbox.Job{Profile: "p"})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		futures = append(futures, f)
+	}
+
+	for _, f := range futures {
+		out, err := f.Wait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "p", out.Raw)
+	}
+
+	assert.Equal(t, int32(2), cl.peak.Load())
+
+	m := pool.Metrics()
+	assert.Equal(t, uint64(6), m.Completed)
+	assert.Equal(t, 0, m.QueueDepth)
+	assert.Greater(t, m.MaxWait, time.Duration(0))
+
+	assert.NoError(t, pool.Shutdown(context.Background()))
+	_, err := pool.Submit(context.Background(), pdftoolbox.Job{Profile: "p"})
+	assert.ErrorIs(t, err, pdftoolbox.ErrPoolClosed)
+}
+
+func TestPoolPriorityOrder(t *testing.T) {
+	cl := &fakeClient{block: make(chan struct{})}
+	pool := pdftoolbox.NewPool(cl, &pdftoolbox.PoolOpts{Concurrency: 1, Ordering: pdftoolbox.PriorityOrder})
+
+	blocker, _ := pool.Submit(context.Background(), pdftoolbox.Job{Profile: "blocker"})
+	// Wait for the blocker to occupy the only seat
+	for cl.running.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	pool.Submit(context.Background(), pdftoolbox.Job{Profile: "low-1", Priority: 1})
+	pool.Submit(context.Background(), pdftoolbox.Job{Profile: "high", Priority: 10})
+	pool.Submit(context.Background(), pdftoolbox.Job{Profile: "low-2", Priority: 1})
+	assert.Equal(t, 3, pool.Metrics().QueueDepth)
+
+	close(cl.block)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+
+	_, err := blocker.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"blocker", "high", "low-1", "low-2"}, cl.order)
+}
+
+func TestPoolShutdownTimeout(t *testing.T) {
+	cl := &fakeClient{block: make(chan struct{})}
+	pool := pdftoolbox.NewPool(cl, nil)
+
+	running, _ := pool.Submit(context.Background(), pdftoolbox.Job{Profile: "blocker"})
+	for cl.running.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	queued, _ := pool.Submit(context.Background(), pdftoolbox.Job{Profile: "queued"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, pool.Shutdown(ctx), context.DeadlineExceeded)
+
+	_, err := running.Wait(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = queued.Wait(context.Background())
+	assert.ErrorIs(t, err, pdftoolbox.ErrPoolClosed)
+}
+
+func TestPoolCancelQueued(t *testing.T) {
+	cl := &fakeClient{block: make(chan struct{})}
+	pool := pdftoolbox.NewPool(cl, nil)
+	defer pool.Shutdown(context.Background())
+	defer close(cl.block)
+
+	pool.Submit(context.Background(), pdftoolbox.Job{Profile: "blocker"})
+	for cl.running.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued, _ := pool.Submit(ctx, pdftoolbox.Job{Profile: "queued"})
+	assert.Equal(t, 1, pool.Metrics().QueueDepth)
+
+	// The future fails as soon as the job is cancelled, while the blocker
+	// still holds the only seat
+	cancel()
+	select {
+	case <-queued.Done():
+	case <-time.After(time.Second):
+		t.Fatal("queued job was not resolved after cancellation")
+	}
+
+	_, err := queued.Wait(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, pool.Metrics().QueueDepth)
+	assert.Equal(t, uint64(1), pool.Metrics().Failed)
+}
+
+func TestPoolDoSharesSeats(t *testing.T) {
+	cl := &fakeClient{block: make(chan struct{})}
+	pool := pdftoolbox.NewPool(cl, nil)
+	defer pool.Shutdown(context.Background())
+
+	started := make(chan struct{})
+	pool.Submit(context.Background(), pdftoolbox.Job{Profile: "blocker", OnStart: func() { close(started) }})
+	<-started
+
+	done := make(chan error, 1)
+	go func() {
+		done <- pool.Do(context.Background(), func(ctx context.Context) error {
+			assert.Equal(t, int32(0), cl.running.Load())
+			return nil
+		})
+	}()
+
+	// Do waits for the seat the blocker holds
+	select {
+	case <-done:
+		t.Fatal("Do ran while the only seat was taken")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(cl.block)
+	assert.NoError(t, <-done)
+	assert.Equal(t, uint64(2), pool.Metrics().Completed)
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package pdftoolbox
+
+import "os/exec"
+
+// setProcessGroup is a no-op on platforms without process groups; the
+// default exec.CommandContext behaviour of killing the child is used.
+func setProcessGroup(cmd *exec.Cmd) {}
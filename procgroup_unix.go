@@ -0,0 +1,25 @@
+//go:build unix
+
+package pdftoolbox
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup puts the command in a new process group and replaces the
+// default context cancellation (which only kills the direct child) with a
+// SIGKILL to the whole group.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
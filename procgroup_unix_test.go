@@ -0,0 +1,34 @@
+//go:build unix
+
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCmdCancelKillsProcessGroup(t *testing.T) {
+	cl, err := New("/bin/sh", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	startedAt := time.Now()
+	// The background sleep inherits stdout; it must be killed with the group
+	// or CombinedOutput would block until it exits.
+	_, err = cl.runCmd(ctx, "-c", "sleep 30 & sleep 30")
+
+	var ce *CancelledError
+	if !assert.True(t, errors.As(err, &ce)) {
+		t.FailNow()
+	}
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(startedAt), waitDelay)
+}
@@ -0,0 +1,165 @@
+package pdftoolbox
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+type QuickCheckOpts struct {
+	// Password opens encrypted input files
+	Password string
+	Timeout  time.Duration
+}
+
+func NewPasswordArg(password string) Arg {
+	return Arg{Arg: "--password", Value: &password}
+}
+
+// QuickCheck reads document information from file with pdfToolbox's
+// --quickcheck mode, without running a profile.
+func (cl *Client) QuickCheck(ctx context.Context, file string, opts *QuickCheckOpts) (*QuickCheckResponse, error) {
+	if err := cl.require(ctx, CapQuickCheck); err != nil {
+		return nil, err
+	}
+
+	var resp QuickCheckResponse
+
+	err := cl.runJSONOutput(ctx, "quickcheck", &resp, func(outputFile string) []string {
+		args := []string{
+			"--quickcheck",
+			"--format=json",
+			NewOutputFileArg(outputFile).ArgString(),
+		}
+		if opts != nil {
+			if opts.Password != "" {
+				args = append(args, NewPasswordArg(opts.Password).ArgString())
+			}
+			if opts.Timeout > 0 {
+				args = append(args, NewTimeoutArg(opts.Timeout).ArgString())
+			}
+		}
+		return cl.seatArgs(append(args, file))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Pages {
+		resp.Pages[i].computeSizes()
+	}
+
+	return &resp, nil
+}
+
+// runJSONOutput runs the command built by args, which tells pdfToolbox to
+// write JSON to outputFile, and decodes that file into v.
+func (cl *Client) runJSONOutput(ctx context.Context, pattern string, v any, args func(outputFile string) []string) error {
+	tmpFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return err
+	}
+	tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = cl.runCmd(ctx, args(tmpFile.Name())...); err != nil {
+		return err
+	}
+
+	f, err := os.Open(tmpFile.Name())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewDecoder(f).Decode(v)
+}
+
+type QuickCheckResponse struct {
+	Information  Information        `json:"information"`
+	Document     QuickCheckDocument `json:"document"`
+	Pages        []QuickCheckPage   `json:"pages"`
+	Fonts        []Font             `json:"fonts"`
+	ColorSpaces  []string           `json:"colorspaces"`
+	OutputIntent *OutputIntent      `json:"output_intent"`
+}
+
+type QuickCheckDocument struct {
+	Path       string   `json:"path"`
+	PDFVersion string   `json:"pdf_version"`
+	Title      string   `json:"title"`
+	Creator    string   `json:"creator"`
+	Producer   string   `json:"producer"`
+	PageCount  int      `json:"page_count"`
+	FileSize   int64    `json:"file_size"`
+	Encrypted  bool     `json:"encrypted"`
+	Standards  []string `json:"standards"`
+}
+
+type QuickCheckPage struct {
+	Number   int      `json:"number"`
+	Rotation int      `json:"rotation"`
+	MediaBox *PageBox `json:"mediabox"`
+	CropBox  *PageBox `json:"cropbox"`
+	TrimBox  *PageBox `json:"trimbox"`
+	BleedBox *PageBox `json:"bleedbox"`
+	ArtBox   *PageBox `json:"artbox"`
+}
+
+func (p *QuickCheckPage) computeSizes() {
+	for _, b := range []*PageBox{p.MediaBox, p.CropBox, p.TrimBox, p.BleedBox, p.ArtBox} {
+		if b != nil {
+			b.computeSizes()
+		}
+	}
+}
+
+// PageBox is a page box as reported by pdfToolbox in PDF points, with its
+// size also given in millimetres.
+type PageBox struct {
+	Left     float64 `json:"left"`
+	Bottom   float64 `json:"bottom"`
+	Right    float64 `json:"right"`
+	Top      float64 `json:"top"`
+	WidthPt  float64 `json:"width_pt"`
+	HeightPt float64 `json:"height_pt"`
+	WidthMM  float64 `json:"width_mm"`
+	HeightMM float64 `json:"height_mm"`
+}
+
+func (b *PageBox) computeSizes() {
+	b.WidthPt = b.Right - b.Left
+	b.HeightPt = b.Top - b.Bottom
+	b.WidthMM = PointsToMM(b.WidthPt)
+	b.HeightMM = PointsToMM(b.HeightPt)
+}
+
+type Font struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Embedded bool   `json:"embedded"`
+	Subset   bool   `json:"subset"`
+}
+
+type OutputIntent struct {
+	Subtype                   string `json:"subtype"`
+	OutputConditionIdentifier string `json:"output_condition_identifier"`
+	OutputCondition           string `json:"output_condition"`
+	RegistryName              string `json:"registry_name"`
+	Info                      string `json:"info"`
+	ProfileName               string `json:"profile_name"`
+}
+
+const pointsPerInch = 72.0
+const mmPerInch = 25.4
+
+// PointsToMM converts PDF points (1/72 inch) to millimetres.
+func PointsToMM(pt float64) float64 {
+	return pt / pointsPerInch * mmPerInch
+}
+
+// MMToPoints converts millimetres to PDF points.
+func MMToPoints(mm float64) float64 {
+	return mm / mmPerInch * pointsPerInch
+}
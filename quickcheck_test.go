@@ -0,0 +1,56 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuickCheck(t *testing.T) {
+	report, err := os.ReadFile("testdata/quickcheck.json")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\nDuration\t00:00\n",
+		Run:    writeOutputFile(string(report)),
+	}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	resp, err := cl.QuickCheck(context.Background(), "in.pdf", &pdftoolbox.QuickCheckOpts{Password: "secret"})
+	if !assert.NoError(t, err) || !assert.Len(t, resp.Pages, 1) {
+		t.FailNow()
+	}
+
+	args := exe.LastCall()
+	assert.Equal(t, "--quickcheck", args[0])
+	assert.Contains(t, args, "--password=secret")
+	assert.Equal(t, "in.pdf", args[len(args)-1])
+
+	assert.Equal(t, "1.6", resp.Document.PDFVersion)
+	assert.Equal(t, "FOGRA39", resp.OutputIntent.OutputConditionIdentifier)
+	assert.Nil(t, resp.Pages[0].BleedBox)
+
+	media := resp.Pages[0].MediaBox
+	assert.InDelta(t, 198.425, media.WidthPt, 0.001)
+	assert.InDelta(t, 70, media.WidthMM, 0.01)
+
+	trim := resp.Pages[0].TrimBox
+	assert.InDelta(t, 55, trim.WidthMM, 0.01)
+	assert.InDelta(t, 55, trim.HeightMM, 0.01)
+}
+
+func TestPointsConversion(t *testing.T) {
+	assert.InDelta(t, 25.4, pdftoolbox.PointsToMM(72), 1e-9)
+	assert.InDelta(t, 72, pdftoolbox.MMToPoints(25.4), 1e-9)
+}
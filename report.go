@@ -0,0 +1,245 @@
+package pdftoolbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+type ReportFormat string
+
+const (
+	ReportXML  ReportFormat = "XML"
+	ReportJSON ReportFormat = "JSON"
+)
+
+// ReportCondition restricts when pdfToolbox writes a report.
+type ReportCondition string
+
+const (
+	ReportAlways   ReportCondition = "ALWAYS"
+	ReportIfHits   ReportCondition = "IFHITS"
+	ReportIfNoHits ReportCondition = "IFNOHITS"
+)
+
+// NewReportArg asks pdfToolbox to write a report in the given format to path.
+// When passed to RunProfile, JSON and XML reports are parsed into
+// CmdOutput.Report.
+func NewReportArg(format ReportFormat, path string) Arg {
+	s := fmt.Sprintf("%s,PATH=%s", format, path)
+	return Arg{Arg: "--report", Value: &s}
+}
+
+// NewConditionalReportArg is like NewReportArg but only writes the report
+// when cond is met.
+func NewConditionalReportArg(format ReportFormat, path string, cond ReportCondition) Arg {
+	s := fmt.Sprintf("%s,%s,PATH=%s", format, cond, path)
+	return Arg{Arg: "--report", Value: &s}
+}
+
+// reportRequest is a JSON or XML --report arg.
+type reportRequest struct {
+	format ReportFormat
+	path   string
+	cond   ReportCondition
+}
+
+// reportFromArgs finds the first JSON or XML --report arg.
+func reportFromArgs(args []Arg) (reportRequest, bool) {
+	for _, a := range args {
+		if a.Arg != "--report" || a.Value == nil {
+			continue
+		}
+
+		var req reportRequest
+		for _, opt := range strings.Split(*a.Value, ",") {
+			switch {
+			case strings.HasPrefix(opt, "PATH="):
+				req.path = strings.TrimPrefix(opt, "PATH=")
+			case ReportFormat(opt) == ReportJSON, ReportFormat(opt) == ReportXML:
+				req.format = ReportFormat(opt)
+			case ReportCondition(opt) == ReportIfHits, ReportCondition(opt) == ReportIfNoHits:
+				req.cond = ReportCondition(opt)
+			}
+		}
+
+		if req.format != "" && req.path != "" {
+			return req, true
+		}
+	}
+
+	return reportRequest{}, false
+}
+
+// readReport parses the report req asked for. A missing report is only an
+// error when it was not conditional, as pdfToolbox skips IFHITS and
+// IFNOHITS reports whose condition is not met.
+func readReport(req reportRequest) (*Report, error) {
+	report, err := ParseReportFile(req.path, req.format)
+	if errors.Is(err, fs.ErrNotExist) {
+		if req.cond != "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("pdftoolbox: requested report %s was not written: %w", req.path, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pdftoolbox: parse report %s: %w", req.path, err)
+	}
+
+	return report, nil
+}
+
+type Severity string
+
+const (
+	SeverityInfo    Severity = "Info"
+	SeverityWarning Severity = "Warning"
+	SeverityError   Severity = "Error"
+)
+
+// Report is the parsed --report file. The model covers the document
+// summary, checks, hits and fixups only and has not been verified against
+// the reports of every pdfToolbox version; Raw keeps the file as written for
+// anything it does not cover.
+type Report struct {
+	XMLName  xml.Name       `json:"-" xml:"report"`
+	Document ReportDocument `json:"document" xml:"document"`
+	Checks   []ReportCheck  `json:"checks" xml:"checks>check"`
+	Hits     []ReportHit    `json:"hits" xml:"hits>hit"`
+	Fixups   []ReportFixup  `json:"fixups" xml:"fixups>fixup"`
+	// Raw is the report file, set by ParseReportFile
+	Raw []byte `json:"-" xml:"-"`
+}
+
+type ReportDocument struct {
+	Path       string `json:"path" xml:"path"`
+	Title      string `json:"title" xml:"title"`
+	Creator    string `json:"creator" xml:"creator"`
+	Producer   string `json:"producer" xml:"producer"`
+	PDFVersion string `json:"pdf_version" xml:"pdf_version"`
+	Pages      int    `json:"pages" xml:"pages"`
+	FileSize   int64  `json:"file_size" xml:"file_size"`
+}
+
+// ReportCheck is a single check of the profile with the severity it was
+// configured with and the number of hits it produced.
+type ReportCheck struct {
+	ID       string   `json:"id" xml:"id,attr"`
+	Name     string   `json:"name" xml:"name"`
+	Severity Severity `json:"severity" xml:"severity,attr"`
+	Hits     int      `json:"hits" xml:"hits,attr"`
+}
+
+type ReportHit struct {
+	CheckID  string         `json:"check_id" xml:"check_id,attr"`
+	Severity Severity       `json:"severity" xml:"severity,attr"`
+	Page     int            `json:"page" xml:"page,attr"`
+	Message  string         `json:"message" xml:"message"`
+	Objects  []ReportObject `json:"objects" xml:"object"`
+}
+
+// ReportObject locates the object a hit was found on. BBox is in PDF points
+// as left, bottom, right, top.
+type ReportObject struct {
+	Type string     `json:"type" xml:"type,attr"`
+	ID   string     `json:"id" xml:"id,attr"`
+	BBox [4]float64 `json:"bbox" xml:"-"`
+}
+
+func (o *ReportObject) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		Type string `xml:"type,attr"`
+		ID   string `xml:"id,attr"`
+		BBox string `xml:"bbox,attr"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+
+	o.Type = raw.Type
+	o.ID = raw.ID
+	if raw.BBox != "" {
+		if _, err := fmt.Sscanf(raw.BBox, "%g %g %g %g", &o.BBox[0], &o.BBox[1], &o.BBox[2], &o.BBox[3]); err != nil {
+			return fmt.Errorf("pdftoolbox: invalid bbox %q: %w", raw.BBox, err)
+		}
+	}
+
+	return nil
+}
+
+type ReportFixup struct {
+	ID    string `json:"id" xml:"id,attr"`
+	Name  string `json:"name" xml:"name"`
+	Count int    `json:"count" xml:"count,attr"`
+}
+
+// Errors returns the hits with error severity.
+func (r *Report) Errors() []ReportHit {
+	var hits []ReportHit
+	for _, h := range r.Hits {
+		if h.Severity == SeverityError {
+			hits = append(hits, h)
+		}
+	}
+	return hits
+}
+
+// HitsOnPage returns the hits found on the given 1-based page.
+func (r *Report) HitsOnPage(page int) []ReportHit {
+	var hits []ReportHit
+	for _, h := range r.Hits {
+		if h.Page == page {
+			hits = append(hits, h)
+		}
+	}
+	return hits
+}
+
+func ParseReport(r io.Reader, format ReportFormat) (*Report, error) {
+	var report Report
+
+	switch format {
+	case ReportJSON:
+		if err := json.NewDecoder(r).Decode(&report); err != nil {
+			return nil, err
+		}
+	case ReportXML:
+		if err := xml.NewDecoder(r).Decode(&report); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("pdftoolbox: unsupported report format %q", format)
+	}
+
+	return &report, nil
+}
+
+// ParseReportFile parses the report at path. If format is empty it is
+// detected from the file contents.
+func ParseReportFile(path string, format ReportFormat) (*Report, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "" {
+		format = ReportXML
+		if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+			format = ReportJSON
+		}
+	}
+
+	report, err := ParseReport(bytes.NewReader(b), format)
+	if err != nil {
+		return nil, err
+	}
+	report.Raw = b
+
+	return report, nil
+}
@@ -0,0 +1,59 @@
+package pdftoolbox
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportArgs(t *testing.T) {
+	assert.Equal(t, "--report=JSON,PATH=/tmp/report.json", NewReportArg(ReportJSON, "/tmp/report.json").ArgString())
+	assert.Equal(t, "--report=XML,IFHITS,PATH=/tmp/report.xml", NewConditionalReportArg(ReportXML, "/tmp/report.xml", ReportIfHits).ArgString())
+
+	req, ok := reportFromArgs([]Arg{
+		NewOutputFolderArg("/tmp/out"),
+		NewConditionalReportArg(ReportXML, "/tmp/report.xml", ReportIfHits),
+	})
+	assert.True(t, ok)
+	assert.Equal(t, reportRequest{format: ReportXML, path: "/tmp/report.xml", cond: ReportIfHits}, req)
+
+	_, ok = reportFromArgs([]Arg{NewOutputFolderArg("/tmp")})
+	assert.False(t, ok)
+}
+
+func TestParseReport(t *testing.T) {
+	// The same report in both formats, see testdata/README.md
+	files := map[ReportFormat]string{ReportJSON: "testdata/report.json", ReportXML: "testdata/report.xml"}
+
+	for format, path := range files {
+		report, err := ParseReportFile(path, format)
+		if !assert.NoError(t, err, format) {
+			continue
+		}
+
+		assert.Equal(t, "1.6", report.Document.PDFVersion)
+		assert.Equal(t, 2, report.Document.Pages)
+		assert.Equal(t, []ReportCheck{{ID: "TRIM", Name: "Trim box size", Severity: SeverityError, Hits: 1}}, report.Checks)
+		assert.Len(t, report.Errors(), 1)
+		assert.Len(t, report.HitsOnPage(2), 0)
+
+		hits := report.HitsOnPage(1)
+		if assert.Len(t, hits, 1) && assert.Len(t, hits[0].Objects, 1) {
+			assert.Equal(t, [4]float64{0, 0, 198.43, 198.43}, hits[0].Objects[0].BBox)
+		}
+		assert.Equal(t, []ReportFixup{{ID: "MB", Name: "Set MediaBox", Count: 1}}, report.Fixups)
+	}
+}
+
+func TestReadReportMissing(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "report.json")
+
+	_, err := readReport(reportRequest{format: ReportJSON, path: missing})
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	report, err := readReport(reportRequest{format: ReportJSON, path: missing, cond: ReportIfHits})
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+}
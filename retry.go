@@ -0,0 +1,132 @@
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy retries profile runs that fail with a transient pdfToolbox
+// error, such as a timeout or an unreachable licence server.
+type RetryPolicy struct {
+	// MaxAttempts includes the first run. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the second attempt. Defaults to one
+	// second.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each attempt. Defaults to 2.
+	Multiplier float64
+	// Jitter randomises each backoff by up to this fraction, between 0 and 1.
+	Jitter float64
+	// Retryable decides whether a failure is retried. Defaults to
+	// IsRetryable: timeouts, licence server failures, locked files, failed
+	// writes and low memory.
+	Retryable func(*ParsedError) bool
+}
+
+// Attempt records a single run made under a RetryPolicy.
+type Attempt struct {
+	Number    int
+	StartedAt time.Time
+	Duration  time.Duration
+	ExitCode  int
+	Err       error
+}
+
+// Backoff returns the wait after the given failed attempt (1-based).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = time.Second
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 {
+		d = math.Min(d, float64(p.MaxBackoff))
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d = d * (1 - jitter + 2*jitter*rand.Float64())
+	}
+
+	return time.Duration(d)
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	var pe *ParsedError
+	if !errors.As(err, &pe) {
+		return false
+	}
+
+	if p.Retryable != nil {
+		return p.Retryable(pe)
+	}
+	return IsRetryable(pe)
+}
+
+// runWithRetry calls run until it succeeds, fails with an error the policy
+// does not retry, or the attempts are used up. Every attempt is recorded on
+// the returned output. run is given onEvent with the attempt number set on
+// every event, and a RetryEvent marks the start of each further attempt.
+func (cl *Client) runWithRetry(ctx context.Context, onEvent EventHandler, run func(EventHandler) (CmdOutput, error)) (CmdOutput, error) {
+	maxAttempts := 1
+	if cl.retryPolicy != nil && cl.retryPolicy.MaxAttempts > 1 {
+		maxAttempts = cl.retryPolicy.MaxAttempts
+	}
+
+	var attempts []Attempt
+
+	for n := 1; ; n++ {
+		var handler EventHandler
+		if onEvent != nil {
+			if n > 1 {
+				onEvent(Event{Type: RetryEvent, Attempt: n, Err: attempts[n-2].Err})
+			}
+			handler = func(ev Event) {
+				ev.Attempt = n
+				onEvent(ev)
+			}
+		}
+
+		startedAt := time.Now()
+		output, err := run(handler)
+
+		attempt := Attempt{
+			Number:    n,
+			StartedAt: startedAt,
+			Duration:  time.Since(startedAt),
+			ExitCode:  output.ExitCode,
+			Err:       err,
+		}
+		var pe *ParsedError
+		if errors.As(err, &pe) {
+			attempt.ExitCode = pe.ProcessExitCode
+		}
+		attempts = append(attempts, attempt)
+
+		if err == nil || n >= maxAttempts || !cl.retryPolicy.retryable(err) {
+			output.Attempts = attempts
+			return output, err
+		}
+
+		backoff := cl.retryPolicy.Backoff(n)
+		cl.logger.Debug("retrying command", "attempt", n, "backoff", backoff, "error", err)
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			output.Attempts = attempts
+			return output, &CancelledError{Err: ctx.Err()}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTransientFailure(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Enqueue(
+		pdftoolboxtest.Response{Stdout: "Error\t1009\tLicense server not reachable\n", ExitCode: 100},
+		pdftoolboxtest.Response{Stdout: "Error\t1007\tTimeout\n", ExitCode: 106},
+		pdftoolboxtest.Response{Stdout: "Summary\tErrors\t0\nDuration\t00:01\n"},
+	)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		RetryPolicy: &pdftoolbox.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	if !assert.NoError(t, err) || !assert.Len(t, res.Attempts, 3) {
+		t.FailNow()
+	}
+	var pe *pdftoolbox.ParsedError
+	if assert.True(t, errors.As(res.Attempts[0].Err, &pe)) {
+		assert.Equal(t, int64(1009), pe.Code)
+	}
+	assert.Equal(t, 106, res.Attempts[1].ExitCode)
+	assert.NoError(t, res.Attempts[2].Err)
+	assert.Len(t, exe.Calls(), 3)
+}
+
+func TestRetryStopsOnPermanentFailure(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "Error\t1002\tFile or folder not found\n", ExitCode: 102}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor:    exe,
+		RetryPolicy: &pdftoolbox.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	assert.True(t, errors.Is(err, pdftoolbox.ErrFileNotFound))
+	assert.Len(t, res.Attempts, 1)
+
+	// A custom predicate can retry anything
+	cl, _ = pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		RetryPolicy: &pdftoolbox.RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+			Retryable:      func(pe *pdftoolbox.ParsedError) bool { return true },
+		},
+	})
+	res, err = cl.RunProfile("profile.kfpx", []string{"input.pdf"})
+	assert.Error(t, err)
+	assert.Len(t, res.Attempts, 2)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := pdftoolbox.RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 400*time.Millisecond, p.Backoff(3))
+	assert.Equal(t, time.Second, p.Backoff(10))
+
+	p.Jitter = 0.5
+	for range 20 {
+		d := p.Backoff(2)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 300*time.Millisecond)
+	}
+}
+
+func TestRetryStreamMarksAttempts(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Enqueue(
+		pdftoolboxtest.Response{Stdout: "Step\tFixup\tTrim\nProgress\t50\t%\nError\t1009\tLicense server not reachable\n", ExitCode: 100},
+		pdftoolboxtest.Response{Stdout: "Step\tFixup\tTrim\nProgress\t100\t%\n"},
+	)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor: exe,
+		RetryPolicy: &pdftoolbox.RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+			Retryable:      func(pe *pdftoolbox.ParsedError) bool { return pe.Code == 1009 },
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var events []pdftoolbox.Event
+	_, err = cl.RunProfileStream(context.Background(), "profile.kfpx", []string{"input.pdf"}, func(ev pdftoolbox.Event) {
+		events = append(events, ev)
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, events, 5) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 1, events[1].Attempt)
+	assert.Equal(t, 50, events[1].Progress)
+
+	retry := events[2]
+	assert.Equal(t, pdftoolbox.RetryEvent, retry.Type)
+	assert.Equal(t, 2, retry.Attempt)
+	assert.ErrorContains(t, retry.Err, "License server not reachable")
+
+	assert.Equal(t, pdftoolbox.StepEvent, events[3].Type)
+	assert.Equal(t, 2, events[4].Attempt)
+	assert.Equal(t, 100, events[4].Progress)
+}
@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+// Image is a page rendered by an images job.
+type Image struct {
+	Page int `json:"page"`
+	// Name is the artifact holding the image
+	Name string `json:"name"`
+}
+
+// StandardResult is the result of a validate or convert job.
+type StandardResult struct {
+	Standard pdftoolbox.Standard `json:"standard"`
+	Conforms bool                `json:"conforms"`
+	Hits     []Hit               `json:"hits,omitempty"`
+	// OutputFile is the artifact holding the converted PDF
+	OutputFile string `json:"outputFile,omitempty"`
+}
+
+// buildImagesJob renders the pages of a single upload. It accepts the
+// optional fields "format", "resolution", "colorspace", "pages" and
+// "antialiasing".
+func (s *Server) buildImagesJob(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error) {
+	input, err := singleUpload(uploads, "")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := pdftoolbox.SaveAsImageOpts{
+		Format:       pdftoolbox.ImageFormat(strings.ToUpper(r.FormValue("format"))),
+		ColorSpace:   pdftoolbox.ImageColorSpace(strings.ToUpper(r.FormValue("colorspace"))),
+		Pages:        r.FormValue("pages"),
+		AntiAliasing: r.FormValue("antialiasing") == "true",
+		OutputFolder: outDir,
+	}
+	if opts.Resolution, err = formInt(r, "resolution"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, _ pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error) {
+		rendered, err := s.client.SaveAsImage(ctx, input, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		images := make([]Image, 0, len(rendered))
+		for _, img := range rendered {
+			images = append(images, Image{Page: img.Page, Name: artifactName(outDir, img.Path)})
+		}
+		return nil, images, nil
+	}, nil
+}
+
+// buildMergeJob merges the uploads, in the order they were sent, into the
+// file named by the optional "name" field, merged.pdf by default.
+func (s *Server) buildMergeJob(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error) {
+	output, err := outputPath(r, outDir, "merged.pdf")
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := allUploads(uploads)
+	return func(ctx context.Context, _ pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error) {
+		_, err := s.client.MergePDF(ctx, inputs, output)
+		return nil, nil, err
+	}, nil
+}
+
+// buildSplitJob splits a single upload. It accepts exactly one of
+// "everyNPages", any number of "range" fields or "bookmarkLevel".
+func (s *Server) buildSplitJob(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error) {
+	input, err := singleUpload(uploads, "")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := pdftoolbox.SplitOpts{
+		PageRanges:   r.MultipartForm.Value["range"],
+		OutputFolder: outDir,
+	}
+	if opts.EveryNPages, err = formInt(r, "everyNPages"); err != nil {
+		return nil, err
+	}
+	if opts.BookmarkLevel, err = formInt(r, "bookmarkLevel"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, _ pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error) {
+		_, err := s.client.SplitPDF(ctx, input, opts)
+		return nil, nil, err
+	}, nil
+}
+
+// buildImposeJob imposes the uploads, in the order they were sent, with the
+// profile named by the "profile" field. The "layout" field holds the
+// pdftoolbox.ImposeOpts as JSON; the optional "name" field names the output,
+// imposed.pdf by default. The result is the computed layout.
+func (s *Server) buildImposeJob(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error) {
+	var opts pdftoolbox.ImposeOpts
+	if err := json.Unmarshal([]byte(r.FormValue("layout")), &opts); err != nil {
+		return nil, fmt.Errorf("%w: invalid layout: %v", errBadRequest, err)
+	}
+
+	profile, err := s.profilePath(r.FormValue("profile"))
+	if err != nil {
+		return nil, err
+	}
+	info.Profile = r.FormValue("profile")
+	opts.Profile = profile
+
+	if _, err := pdftoolbox.PreviewLayout(opts); err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+
+	output, err := outputPath(r, outDir, "imposed.pdf")
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := allUploads(uploads)
+	return func(ctx context.Context, _ pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error) {
+		res, err := s.client.Impose(ctx, inputs, output, opts)
+		if res.Output.Raw == "" {
+			// Failed before pdfToolbox ran
+			return nil, nil, err
+		}
+		return &res.Output, res.Layout, err
+	}, nil
+}
+
+// buildCompareJob compares the upload in the "a" field with the one in the
+// "b" field. It accepts the optional fields "resolution", "threshold" and
+// "report", which writes a diff report PDF when "true".
+func (s *Server) buildCompareJob(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error) {
+	a, err := singleUpload(uploads, "a")
+	if err != nil {
+		return nil, err
+	}
+	b, err := singleUpload(uploads, "b")
+	if err != nil {
+		return nil, err
+	}
+
+	var opts pdftoolbox.CompareOpts
+	if opts.Resolution, err = formInt(r, "resolution"); err != nil {
+		return nil, err
+	}
+	if t := r.FormValue("threshold"); t != "" {
+		if opts.Threshold, err = strconv.ParseFloat(t, 64); err != nil {
+			return nil, fmt.Errorf("%w: invalid threshold %q", errBadRequest, t)
+		}
+	}
+	if r.FormValue("report") == "true" {
+		opts.ReportPDF = filepath.Join(outDir, "diff.pdf")
+	}
+
+	return func(ctx context.Context, _ pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error) {
+		res, err := s.client.Compare(ctx, a, b, &opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if res.ReportPath != "" {
+			res.ReportPath = artifactName(outDir, res.ReportPath)
+		}
+		return nil, res, nil
+	}, nil
+}
+
+// buildStandardJob validates a single upload against the standard in the
+// "standard" field, or converts it when convert is set.
+func (s *Server) buildStandardJob(convert bool) jobBuilder {
+	return func(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error) {
+		input, err := singleUpload(uploads, "")
+		if err != nil {
+			return nil, err
+		}
+
+		standard := pdftoolbox.Standard(r.FormValue("standard"))
+		if standard == "" {
+			return nil, fmt.Errorf("%w: no standard", errBadRequest)
+		}
+
+		opts := &pdftoolbox.StandardOpts{ProfileFolder: s.opts.ProfileFolder}
+		if convert {
+			opts.OutputFile = filepath.Join(outDir, filepath.Base(input))
+		}
+
+		return func(ctx context.Context, _ pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error) {
+			run := s.client.Validate
+			if convert {
+				run = s.client.ConvertTo
+			}
+
+			res, err := run(ctx, standard, input, opts)
+			if res == nil {
+				return nil, nil, err
+			}
+
+			result := StandardResult{
+				Standard: res.Standard,
+				Conforms: res.Conforms,
+				Hits:     toHits(res.Hits),
+			}
+			if res.OutputPath != "" {
+				result.OutputFile = artifactName(outDir, res.OutputPath)
+			}
+			return &res.Output, result, err
+		}, nil
+	}
+}
+
+// singleUpload returns the only file uploaded in field, or in any field when
+// field is empty.
+func singleUpload(uploads []upload, field string) (string, error) {
+	var files []string
+	for _, u := range uploads {
+		if field == "" || u.field == field {
+			files = append(files, u.path)
+		}
+	}
+	if len(files) != 1 {
+		if field == "" {
+			return "", fmt.Errorf("%w: expected exactly one file", errBadRequest)
+		}
+		return "", fmt.Errorf("%w: expected exactly one file in %q", errBadRequest, field)
+	}
+	return files[0], nil
+}
+
+// outputPath returns where a job writes its single output file, named by
+// the optional "name" field.
+func outputPath(r *http.Request, outDir, fallback string) (string, error) {
+	name := r.FormValue("name")
+	if name == "" {
+		name = fallback
+	}
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%w: invalid output name %q", errBadRequest, name)
+	}
+	return filepath.Join(outDir, name), nil
+}
+
+// formInt returns the integer in the optional field name, or 0 when it is
+// empty.
+func formInt(r *http.Request, name string) (int, error) {
+	v := r.FormValue(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: invalid %s %q", errBadRequest, name, v)
+	}
+	return n, nil
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+var (
+	errBadRequest = errors.New("bad request")
+	errNotFound   = errors.New("not found")
+)
+
+// ErrorInfo is the JSON form of an error, both in error responses and in the
+// status of failed jobs.
+type ErrorInfo struct {
+	Message string `json:"message"`
+	// Code is the pdfToolbox error code, if any
+	Code pdftoolbox.ReasonCode `json:"code,omitempty"`
+	// Class is one of "retryable", "config" or "input" when known
+	Class    string `json:"class,omitempty"`
+	ExitCode int    `json:"exitCode,omitempty"`
+}
+
+func newErrorInfo(err error) *ErrorInfo {
+	info := &ErrorInfo{Message: err.Error()}
+
+	var pe *pdftoolbox.ParsedError
+	if errors.As(err, &pe) {
+		info.Code = pe.Reason()
+		info.ExitCode = pe.ProcessExitCode
+	}
+
+	switch pdftoolbox.Classify(err) {
+	case pdftoolbox.ClassRetryable:
+		info.Class = "retryable"
+	case pdftoolbox.ClassConfig:
+		info.Class = "config"
+	case pdftoolbox.ClassInput:
+		info.Class = "input"
+	}
+
+	return info
+}
+
+func errorStatus(err error) int {
+	var mbe *http.MaxBytesError
+	var ce *pdftoolbox.CancelledError
+
+	switch {
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, errNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &mbe):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, pdftoolbox.ErrUnsupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, pdftoolbox.ErrPoolClosed), errors.As(err, &ce):
+		return http.StatusServiceUnavailable
+	}
+
+	switch pdftoolbox.Classify(err) {
+	case pdftoolbox.ClassInput:
+		return http.StatusUnprocessableEntity
+	case pdftoolbox.ClassRetryable, pdftoolbox.ClassConfig:
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, errorStatus(err), map[string]*ErrorInfo{"error": newErrorInfo(err)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,411 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Done reports whether the job has finished, successfully or not.
+func (s JobStatus) Done() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
+
+// JobKind is the pdfToolbox command a job runs.
+type JobKind string
+
+const (
+	JobProfile  JobKind = "profile"
+	JobImages   JobKind = "images"
+	JobMerge    JobKind = "merge"
+	JobSplit    JobKind = "split"
+	JobImpose   JobKind = "impose"
+	JobCompare  JobKind = "compare"
+	JobValidate JobKind = "validate"
+	JobConvert  JobKind = "convert"
+)
+
+// JobInfo is the JSON returned when a job is submitted or polled.
+type JobInfo struct {
+	ID        string            `json:"id"`
+	Kind      JobKind           `json:"kind"`
+	Status    JobStatus         `json:"status"`
+	Profile   string            `json:"profile,omitempty"`
+	Inputs    []string          `json:"inputs"`
+	Variables map[string]string `json:"variables,omitempty"`
+	// Step and Progress follow the output of the running job
+	Step        string     `json:"step,omitempty"`
+	Progress    int        `json:"progress"`
+	SubmittedAt time.Time  `json:"submittedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	// WaitedMs is how long the job was queued before it started
+	WaitedMs  int64      `json:"waitedMs,omitempty"`
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+	// Output is the pdfToolbox output of profile runs, also of failed ones
+	Output *Output `json:"output,omitempty"`
+	// Result is the outcome of the other kinds of job, such as the rendered
+	// pages of an images job
+	Result any        `json:"result,omitempty"`
+	Error  *ErrorInfo `json:"error,omitempty"`
+}
+
+// Artifact is an output file of a job, downloadable from URL.
+type Artifact struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Size     int64  `json:"size"`
+	MIMEType string `json:"mimeType,omitempty"`
+}
+
+type job struct {
+	info   JobInfo
+	dir    string
+	outDir string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// jobFunc runs a job on a pool worker. It returns the pdfToolbox output,
+// if the command has one, and the result reported as JobInfo.Result.
+type jobFunc func(ctx context.Context, onEvent pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error)
+
+// jobBuilder checks the request of a job whose uploads were saved, fills in
+// the request details of info and returns what the job runs. uploads are the
+// saved files in the order they were sent.
+type jobBuilder func(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error)
+
+// handleJob returns a handler that queues a job of kind built by build and
+// answers with its JobInfo.
+func (s *Server) handleJob(kind JobKind, build jobBuilder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.pruneJobs()
+
+		id := newID()
+		dir := filepath.Join(s.opts.WorkDir, id)
+
+		j, err := s.newJob(r, kind, build, id, dir)
+		if err != nil {
+			os.RemoveAll(dir)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, s.jobInfo(j))
+	}
+}
+
+func (s *Server) newJob(r *http.Request, kind JobKind, build jobBuilder, id, dir string) (*job, error) {
+	inDir, outDir := filepath.Join(dir, "in"), filepath.Join(dir, "out")
+	for _, d := range []string{inDir, outDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	uploads, err := saveUploadsByField(r, inDir)
+	if err != nil {
+		return nil, err
+	}
+	inputs := allUploads(uploads)
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: no input files", errBadRequest)
+	}
+
+	j := &job{
+		info: JobInfo{
+			ID:          id,
+			Kind:        kind,
+			Status:      JobQueued,
+			SubmittedAt: time.Now(),
+		},
+		dir:    dir,
+		outDir: outDir,
+		done:   make(chan struct{}),
+	}
+	for _, in := range inputs {
+		j.info.Inputs = append(j.info.Inputs, filepath.Base(in))
+	}
+
+	run, err := build(r, uploads, outDir, &j.info)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	j.cancel = cancel
+
+	future, err := s.pool.Submit(ctx, pdftoolbox.Job{
+		OnStart: func() { s.setRunning(j) },
+		Run: func(ctx context.Context) (pdftoolbox.CmdOutput, error) {
+			out, result, err := run(ctx, func(ev pdftoolbox.Event) { s.onEvent(j, ev) })
+
+			s.mu.Lock()
+			j.info.Result = result
+			if out != nil {
+				j.info.Output = newOutput(*out, outDir)
+			}
+			s.mu.Unlock()
+
+			if out == nil {
+				return pdftoolbox.CmdOutput{}, err
+			}
+			return *out, err
+		},
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.jobs[id] = j
+	s.mu.Unlock()
+
+	go func() {
+		<-future.Done()
+		cancel()
+		s.finish(j, future.Result())
+	}()
+
+	return j, nil
+}
+
+// buildProfileJob accepts a multipart form with a "profile" field, any
+// number of "var" fields in the form key=value, an optional "timeout" in
+// seconds and the input PDFs as file parts.
+func (s *Server) buildProfileJob(r *http.Request, uploads []upload, outDir string, info *JobInfo) (jobFunc, error) {
+	profileName := r.FormValue("profile")
+	profile, err := s.profilePath(profileName)
+	if err != nil {
+		return nil, err
+	}
+	info.Profile = profileName
+
+	args := []pdftoolbox.Arg{pdftoolbox.NewOutputFolderArg(outDir)}
+	vars := map[string]string{}
+	for _, v := range r.MultipartForm.Value["var"] {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: variable %q is not key=value", errBadRequest, v)
+		}
+		vars[key] = value
+		args = append(args, pdftoolbox.NewSetVariableArg(key, value))
+	}
+	if len(vars) > 0 {
+		info.Variables = vars
+	}
+	if t := r.FormValue("timeout"); t != "" {
+		secs, err := strconv.Atoi(t)
+		if err != nil || secs <= 0 {
+			return nil, fmt.Errorf("%w: invalid timeout %q", errBadRequest, t)
+		}
+		args = append(args, pdftoolbox.NewTimeoutArg(time.Duration(secs)*time.Second))
+	}
+
+	inputs := allUploads(uploads)
+	return func(ctx context.Context, onEvent pdftoolbox.EventHandler) (*pdftoolbox.CmdOutput, any, error) {
+		out, err := s.client.RunProfileStream(ctx, profile, inputs, onEvent, args...)
+		return &out, nil, err
+	}, nil
+}
+
+// setRunning marks a job as running once a pool worker has taken it.
+func (s *Server) setRunning(j *job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j.info.Status = JobRunning
+}
+
+func (s *Server) onEvent(j *job, ev pdftoolbox.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j.info.Step = ev.Step
+	switch ev.Type {
+	case pdftoolbox.ProgressEvent:
+		j.info.Progress = ev.Progress
+	case pdftoolbox.RetryEvent:
+		// The run starts over
+		j.info.Progress = 0
+	}
+}
+
+func (s *Server) finish(j *job, res pdftoolbox.JobResult) {
+	artifacts := s.artifacts(j)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer close(j.done)
+
+	now := time.Now()
+	j.info.FinishedAt = &now
+	j.info.WaitedMs = res.Waited.Milliseconds()
+	j.info.Artifacts = artifacts
+
+	var ce *pdftoolbox.CancelledError
+	switch {
+	case res.Err == nil:
+		j.info.Status = JobSucceeded
+		j.info.Progress = 100
+	case errors.As(res.Err, &ce),
+		// A job cancelled in the queue ends with the context's error
+		errors.Is(res.Err, context.Canceled),
+		errors.Is(res.Err, context.DeadlineExceeded):
+		j.info.Status = JobCancelled
+		j.info.Error = newErrorInfo(res.Err)
+	default:
+		j.info.Status = JobFailed
+		j.info.Error = newErrorInfo(res.Err)
+		s.opts.Logger.Warn("job failed", "id", j.info.ID, "error", res.Err)
+	}
+}
+
+// artifacts lists the files a job wrote to its output folder, whether or
+// not pdfToolbox printed an Output line for them.
+func (s *Server) artifacts(j *job) []Artifact {
+	var out []Artifact
+	filepath.WalkDir(j.outDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		name, _ := filepath.Rel(j.outDir, path)
+		name = filepath.ToSlash(name)
+
+		out = append(out, Artifact{
+			Name:     name,
+			URL:      "/jobs/" + j.info.ID + "/artifacts/" + (&url.URL{Path: name}).EscapedPath(),
+			Size:     fi.Size(),
+			MIMEType: mime.TypeByExtension(filepath.Ext(name)),
+		})
+		return nil
+	})
+	return out
+}
+
+func (s *Server) jobInfo(j *job) JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return j.info
+}
+
+func (s *Server) lookup(id string) (*job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: job %s", errNotFound, id)
+	}
+	return j, nil
+}
+
+func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	infos := make([]JobInfo, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		info := j.info
+		// The full output is only returned for a single job
+		info.Output = nil
+		infos = append(infos, info)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(infos, func(a, b int) bool {
+		return infos[a].SubmittedAt.Before(infos[b].SubmittedAt)
+	})
+
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	j, err := s.lookup(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.jobInfo(j))
+}
+
+// handleDeleteJob cancels a job if it is still queued or running and removes
+// its files.
+func (s *Server) handleDeleteJob(w http.ResponseWriter, r *http.Request) {
+	j, err := s.lookup(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.jobs, j.info.ID)
+	s.mu.Unlock()
+
+	j.cancel()
+	go func() {
+		<-j.done
+		os.RemoveAll(j.dir)
+	}()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleArtifact(w http.ResponseWriter, r *http.Request) {
+	j, err := s.lookup(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	name := r.PathValue("name")
+	for _, a := range s.jobInfo(j).Artifacts {
+		if a.Name == name {
+			if a.MIMEType != "" {
+				w.Header().Set("Content-Type", a.MIMEType)
+			}
+			http.ServeFile(w, r, filepath.Join(j.outDir, filepath.FromSlash(name)))
+			return
+		}
+	}
+
+	writeError(w, fmt.Errorf("%w: artifact %s", errNotFound, name))
+}
+
+// pruneJobs removes finished jobs older than the JobTTL.
+func (s *Server) pruneJobs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, j := range s.jobs {
+		if j.info.FinishedAt != nil && time.Since(*j.info.FinishedAt) > s.opts.JobTTL {
+			os.RemoveAll(j.dir)
+			delete(s.jobs, id)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func submitJob(t *testing.T, s *Server) *job {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("profile", "fix.kfpx")
+	fw, _ := mw.CreateFormFile("file", "in.pdf")
+	fw.Write([]byte("%PDF-1.7"))
+	mw.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/jobs", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if !assert.Equal(t, http.StatusAccepted, w.Code) {
+		t.FailNow()
+	}
+
+	var info JobInfo
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+
+	j, err := s.lookup(info.ID)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return j
+}
+
+func TestCancelQueuedJob(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n", Delay: 200 * time.Millisecond}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, Logger: logger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	s, err := New(cl, &Opts{ProfileFolder: "/profiles", WorkDir: t.TempDir(), Concurrency: 1, Logger: logger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { s.Close(context.Background()) })
+
+	running := submitJob(t, s)
+	queued := submitJob(t, s)
+	assert.Equal(t, JobQueued, s.jobInfo(queued).Status)
+
+	queued.cancel()
+	<-queued.done
+	assert.Equal(t, JobCancelled, s.jobInfo(queued).Status)
+
+	<-running.done
+	assert.Equal(t, JobSucceeded, s.jobInfo(running).Status)
+}
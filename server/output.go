@@ -0,0 +1,111 @@
+package server
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+// Output is the JSON form of the output of a profile run. Files are named
+// relative to the job's output folder, like Artifact.Name.
+type Output struct {
+	ExitCode   int                `json:"exitCode"`
+	DurationMs int64              `json:"durationMs"`
+	Summary    pdftoolbox.Summary `json:"summary"`
+	Verdict    pdftoolbox.Verdict `json:"verdict"`
+	Hits       []Hit              `json:"hits,omitempty"`
+	Fixes      []string           `json:"fixes,omitempty"`
+	Steps      []Step             `json:"steps,omitempty"`
+	Attempts   []Attempt          `json:"attempts,omitempty"`
+	Satellite  string             `json:"satellite,omitempty"`
+	Raw        string             `json:"raw"`
+}
+
+type Hit struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+type Step struct {
+	Name        string             `json:"name"`
+	Summary     pdftoolbox.Summary `json:"summary"`
+	Hits        []Hit              `json:"hits,omitempty"`
+	Fixes       []string           `json:"fixes,omitempty"`
+	OutputFiles []string           `json:"outputFiles,omitempty"`
+}
+
+// Attempt is a run made for a job, more than one when it was retried.
+type Attempt struct {
+	Number     int       `json:"number"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+	ExitCode   int       `json:"exitCode"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func newOutput(out pdftoolbox.CmdOutput, outDir string) *Output {
+	o := &Output{
+		ExitCode:   out.ExitCode,
+		DurationMs: out.Duration.Milliseconds(),
+		Summary:    out.Summary,
+		Verdict:    out.Summary.Verdict(),
+		Hits:       toHits(out.Hits),
+		Fixes:      toFixes(out.Fixes),
+		Satellite:  out.Satellite,
+		Raw:        out.Raw,
+	}
+
+	for _, step := range out.Steps {
+		st := Step{
+			Name:    step.Name,
+			Summary: step.Summary,
+			Hits:    toHits(step.Hits),
+			Fixes:   toFixes(step.Fixes),
+		}
+		for _, p := range step.OutputFilePaths {
+			st.OutputFiles = append(st.OutputFiles, artifactName(outDir, p))
+		}
+		o.Steps = append(o.Steps, st)
+	}
+
+	for _, a := range out.Attempts {
+		at := Attempt{
+			Number:     a.Number,
+			StartedAt:  a.StartedAt,
+			DurationMs: a.Duration.Milliseconds(),
+			ExitCode:   a.ExitCode,
+		}
+		if a.Err != nil {
+			at.Error = a.Err.Error()
+		}
+		o.Attempts = append(o.Attempts, at)
+	}
+
+	return o
+}
+
+func toHits(lines []pdftoolbox.CmdOutputHitLine) []Hit {
+	var hits []Hit
+	for _, l := range lines {
+		hits = append(hits, Hit{Severity: l.Severity, Message: l.Message})
+	}
+	return hits
+}
+
+func toFixes(lines []pdftoolbox.CmdOutputFixLine) []string {
+	var fixes []string
+	for _, l := range lines {
+		fixes = append(fixes, l.Name)
+	}
+	return fixes
+}
+
+// artifactName returns path relative to the output folder, or its base name
+// for files written elsewhere.
+func artifactName(outDir, path string) string {
+	if rel, err := filepath.Rel(outDir, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Base(path)
+}
@@ -0,0 +1,366 @@
+// Package server exposes a pdftoolbox.Client over HTTP, so that services on
+// other hosts can share a single licensed pdfToolbox installation.
+//
+// Profile runs are asynchronous: POST /jobs uploads the input files and
+// returns a job that can be polled with GET /jobs/{id} and whose output
+// files are downloaded from GET /jobs/{id}/artifacts/{name}. Rendering
+// images, merging, splitting, imposing, comparing, validating and
+// converting are jobs too, submitted to POST /images, /merge, /split,
+// /impose, /compare, /validate and /convert. Quick checks, profile
+// enumeration, licence and version queries answer synchronously. Every
+// pdfToolbox command runs on the server's pool, so together they never use
+// more than Opts.Concurrency seats.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+)
+
+type Opts struct {
+	// ProfileFolder holds the profiles jobs may run. Profiles are referred to
+	// by their path relative to it.
+	ProfileFolder string
+	// WorkDir holds uploads and outputs, one folder per job. Defaults to a
+	// folder in os.TempDir.
+	WorkDir string
+	// Concurrency is the number of jobs run at once, usually the number of
+	// licence seats. Defaults to 1.
+	Concurrency int
+	// MaxUploadSize limits the size of a request body. Defaults to 512 MiB.
+	MaxUploadSize int64
+	// JobTTL is how long finished jobs and their files are kept. Defaults to
+	// one hour.
+	JobTTL time.Duration
+	Logger *slog.Logger
+}
+
+type Server struct {
+	client *pdftoolbox.Client
+	pool   *pdftoolbox.Pool
+	opts   Opts
+	mux    *http.ServeMux
+
+	mu   sync.Mutex
+	jobs map[string]*job
+
+	// ctx is the parent of every job and is cancelled by Close
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func New(client *pdftoolbox.Client, opts *Opts) (*Server, error) {
+	s := &Server{
+		client: client,
+		opts: Opts{
+			WorkDir:       filepath.Join(os.TempDir(), "pdftoolbox-server"),
+			Concurrency:   1,
+			MaxUploadSize: 512 << 20,
+			JobTTL:        time.Hour,
+			Logger:        slog.Default(),
+		},
+		jobs: map[string]*job{},
+	}
+
+	if opts != nil {
+		s.opts.ProfileFolder = opts.ProfileFolder
+		if opts.WorkDir != "" {
+			s.opts.WorkDir = opts.WorkDir
+		}
+		if opts.Concurrency > 0 {
+			s.opts.Concurrency = opts.Concurrency
+		}
+		if opts.MaxUploadSize > 0 {
+			s.opts.MaxUploadSize = opts.MaxUploadSize
+		}
+		if opts.JobTTL > 0 {
+			s.opts.JobTTL = opts.JobTTL
+		}
+		if opts.Logger != nil {
+			s.opts.Logger = opts.Logger
+		}
+	}
+
+	if s.opts.ProfileFolder == "" {
+		return nil, errors.New("server: a profile folder is required")
+	}
+	if err := os.MkdirAll(s.opts.WorkDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	s.pool = pdftoolbox.NewPool(client, &pdftoolbox.PoolOpts{Concurrency: s.opts.Concurrency})
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("POST /jobs", s.handleJob(JobProfile, s.buildProfileJob))
+	s.mux.HandleFunc("POST /images", s.handleJob(JobImages, s.buildImagesJob))
+	s.mux.HandleFunc("POST /merge", s.handleJob(JobMerge, s.buildMergeJob))
+	s.mux.HandleFunc("POST /split", s.handleJob(JobSplit, s.buildSplitJob))
+	s.mux.HandleFunc("POST /impose", s.handleJob(JobImpose, s.buildImposeJob))
+	s.mux.HandleFunc("POST /compare", s.handleJob(JobCompare, s.buildCompareJob))
+	s.mux.HandleFunc("POST /validate", s.handleJob(JobValidate, s.buildStandardJob(false)))
+	s.mux.HandleFunc("POST /convert", s.handleJob(JobConvert, s.buildStandardJob(true)))
+	s.mux.HandleFunc("GET /jobs", s.handleListJobs)
+	s.mux.HandleFunc("GET /jobs/{id}", s.handleGetJob)
+	s.mux.HandleFunc("DELETE /jobs/{id}", s.handleDeleteJob)
+	s.mux.HandleFunc("GET /jobs/{id}/artifacts/{name...}", s.handleArtifact)
+	s.mux.HandleFunc("GET /profiles", s.handleProfiles)
+	s.mux.HandleFunc("POST /quickcheck", s.handleQuickCheck)
+	s.mux.HandleFunc("GET /license", s.handleLicense)
+	s.mux.HandleFunc("GET /version", s.handleVersion)
+	s.mux.HandleFunc("GET /metrics", s.handleMetrics)
+
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.opts.MaxUploadSize)
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close stops accepting jobs and waits for running ones until ctx is done,
+// after which they are killed. Job files are removed.
+func (s *Server) Close(ctx context.Context) error {
+	err := s.pool.Shutdown(ctx)
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, j := range s.jobs {
+		os.RemoveAll(j.dir)
+		delete(s.jobs, id)
+	}
+
+	return err
+}
+
+// Pool returns the pool jobs are run on, for sharing it with other
+// front ends of the same client.
+func (s *Server) Pool() *pdftoolbox.Pool {
+	return s.pool
+}
+
+func (s *Server) profilePath(name string) (string, error) {
+	if name == "" || !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%w: invalid profile %q", errBadRequest, name)
+	}
+	return filepath.Join(s.opts.ProfileFolder, name), nil
+}
+
+func (s *Server) handleProfiles(w http.ResponseWriter, r *http.Request) {
+	catalog, err := s.client.Catalog(s.opts.ProfileFolder)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var resp *pdftoolbox.EnumerateProfilesResponse
+	err = s.pool.Do(r.Context(), func(ctx context.Context) (err error) {
+		resp, err = catalog.Response(ctx)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleQuickCheck(w http.ResponseWriter, r *http.Request) {
+	dir, err := os.MkdirTemp(s.opts.WorkDir, "quickcheck-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	files, err := saveUploads(r, dir)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(files) != 1 {
+		writeError(w, fmt.Errorf("%w: expected exactly one file", errBadRequest))
+		return
+	}
+
+	var resp *pdftoolbox.QuickCheckResponse
+	err = s.pool.Do(r.Context(), func(ctx context.Context) (err error) {
+		resp, err = s.client.QuickCheck(ctx, files[0], &pdftoolbox.QuickCheckOpts{
+			Password: r.FormValue("password"),
+		})
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleLicense(w http.ResponseWriter, r *http.Request) {
+	var status *pdftoolbox.LicenseStatus
+	err := s.pool.Do(r.Context(), func(ctx context.Context) (err error) {
+		status, err = s.client.LicenseStatus(ctx)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	var v pdftoolbox.Version
+	err := s.pool.Do(r.Context(), func(ctx context.Context) (err error) {
+		v, err = s.client.Version(ctx)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	m := s.pool.Metrics()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"queueDepth":    m.QueueDepth,
+		"running":       m.Running,
+		"completed":     m.Completed,
+		"failed":        m.Failed,
+		"averageWaitMs": m.AverageWait().Milliseconds(),
+		"maxWaitMs":     m.MaxWait.Milliseconds(),
+	})
+}
+
+// saveUploads writes every file part of a multipart request to dir and
+// returns their paths.
+func saveUploads(r *http.Request, dir string) ([]string, error) {
+	uploads, err := saveUploadsByField(r, dir)
+	if err != nil {
+		return nil, err
+	}
+	return allUploads(uploads), nil
+}
+
+// upload is a file saved from a multipart request.
+type upload struct {
+	field string
+	path  string
+}
+
+// maxFormValueSize limits the non-file fields of a multipart request, as
+// ParseMultipartForm does.
+const maxFormValueSize = 10 << 20
+
+// saveUploadsByField writes every file part of a multipart request to dir
+// and returns them with their form field names, in the order the parts were
+// sent. The other fields are read into r.MultipartForm and r.Form as
+// ParseMultipartForm would.
+func saveUploadsByField(r *http.Request, dir string) ([]upload, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+
+	values := url.Values{}
+	valueSize := int64(0)
+	var uploads []upload
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+
+		field := part.FormName()
+		if field == "" {
+			continue
+		}
+
+		if part.FileName() == "" {
+			b, err := io.ReadAll(io.LimitReader(part, maxFormValueSize-valueSize+1))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+			}
+			if valueSize += int64(len(b)); valueSize > maxFormValueSize {
+				return nil, fmt.Errorf("%w: form fields too large", errBadRequest)
+			}
+			values.Add(field, string(b))
+			continue
+		}
+
+		p, err := saveUpload(part.FileName(), part, dir)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload{field: field, path: p})
+	}
+
+	r.MultipartForm = &multipart.Form{Value: values}
+	r.PostForm = values
+	r.Form = r.URL.Query()
+	for k, vs := range values {
+		r.Form[k] = append(r.Form[k], vs...)
+	}
+
+	return uploads, nil
+}
+
+// allUploads returns the paths of every upload, in the order they were
+// sent.
+func allUploads(uploads []upload) []string {
+	paths := make([]string, 0, len(uploads))
+	for _, u := range uploads {
+		paths = append(paths, u.path)
+	}
+	return paths
+}
+
+func saveUpload(filename string, src io.Reader, dir string) (string, error) {
+	name := filepath.Base(filepath.Clean("/" + filename))
+	if name == "/" || name == "." {
+		return "", fmt.Errorf("%w: invalid file name %q", errBadRequest, filename)
+	}
+
+	p := filepath.Join(dir, name)
+	dst, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return "", fmt.Errorf("%w: duplicate file name %q", errBadRequest, name)
+		}
+		return "", err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return "", err
+	}
+
+	return p, dst.Close()
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,367 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/fikastudio/pdftoolbox-go/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, exe *pdftoolboxtest.Executor) *httptest.Server {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, Logger: logger})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	srv, err := server.New(cl, &server.Opts{
+		ProfileFolder: "/profiles",
+		WorkDir:       t.TempDir(),
+		Concurrency:   2,
+		Logger:        logger,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ts := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		ts.Close()
+		srv.Close(context.Background())
+	})
+
+	return ts
+}
+
+func upload(t *testing.T, url string, fields map[string][]string, files map[string]string) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, vs := range fields {
+		for _, v := range vs {
+			mw.WriteField(k, v)
+		}
+	}
+	for name, content := range files {
+		fw, _ := mw.CreateFormFile("file", name)
+		fw.Write([]byte(content))
+	}
+	mw.Close()
+
+	resp, err := http.Post(url, mw.FormDataContentType(), &body)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return resp
+}
+
+func decode[T any](t *testing.T, resp *http.Response) T {
+	t.Helper()
+	defer resp.Body.Close()
+
+	var v T
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&v))
+	return v
+}
+
+func waitForJob(t *testing.T, ts *httptest.Server, id string) server.JobInfo {
+	t.Helper()
+
+	var info server.JobInfo
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get(ts.URL + "/jobs/" + id)
+		if err != nil {
+			return false
+		}
+		info = decode[server.JobInfo](t, resp)
+		return info.Status.Done()
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return info
+}
+
+func writeOutputFile(content string) func(args []string) error {
+	return func(args []string) error {
+		for _, a := range args {
+			if path, ok := strings.CutPrefix(a, "--outputfile="); ok {
+				return os.WriteFile(path, []byte(content), 0o644)
+			}
+		}
+		return nil
+	}
+}
+
+func TestSubmitJob(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:      "ProcessID\t1\nStep\tFix\nProgress\t50\nOutput\t{outputfolder}/in_fixed.pdf\nProgress\t100\n",
+		OutputFiles: map[string][]byte{"in_fixed.pdf": []byte("%PDF-1.7 fixed")},
+		LineDelay:   5 * time.Millisecond,
+	}
+
+	ts := newTestServer(t, exe)
+
+	resp := upload(t, ts.URL+"/jobs", map[string][]string{
+		"profile": {"fix.kfpx"},
+		"var":     {"trimWidth=55", "trimHeight=55"},
+	}, map[string]string{"in.pdf": "%PDF-1.7"})
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	info := decode[server.JobInfo](t, resp)
+	assert.NotEmpty(t, info.ID)
+	assert.Equal(t, []string{"in.pdf"}, info.Inputs)
+
+	info = waitForJob(t, ts, info.ID)
+	assert.Equal(t, server.JobSucceeded, info.Status)
+	assert.Equal(t, 100, info.Progress)
+	assert.Equal(t, map[string]string{"trimWidth": "55", "trimHeight": "55"}, info.Variables)
+	if assert.NotNil(t, info.Output) && assert.Len(t, info.Output.Steps, 1) {
+		assert.Equal(t, []string{"in_fixed.pdf"}, info.Output.Steps[0].OutputFiles)
+	}
+
+	args := exe.LastCall()
+	assert.Contains(t, args, "--setvariable=trimWidth:55")
+	assert.Contains(t, args, "/profiles/fix.kfpx")
+
+	if !assert.Len(t, info.Artifacts, 1) {
+		t.FailNow()
+	}
+	assert.Equal(t, "in_fixed.pdf", info.Artifacts[0].Name)
+	assert.Equal(t, "application/pdf", info.Artifacts[0].MIMEType)
+
+	resp, err := http.Get(ts.URL + info.Artifacts[0].URL)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "%PDF-1.7 fixed", string(b))
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/jobs/"+info.ID, nil)
+	resp, err = http.DefaultClient.Do(req)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+
+	resp, err = http.Get(ts.URL + "/jobs/" + info.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestSubmitJobFailed(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:   "ProcessID\t1\nError\t1002\tCould not open file: File or folder not found\n",
+		ExitCode: 102,
+	}
+
+	ts := newTestServer(t, exe)
+
+	resp := upload(t, ts.URL+"/jobs", map[string][]string{"profile": {"fix.kfpx"}}, map[string]string{"in.pdf": "broken"})
+	info := waitForJob(t, ts, decode[server.JobInfo](t, resp).ID)
+
+	assert.Equal(t, server.JobFailed, info.Status)
+	if assert.NotNil(t, info.Error) {
+		assert.Equal(t, pdftoolbox.CodeFileNotFound, info.Error.Code)
+		assert.Equal(t, "input", info.Error.Class)
+	}
+}
+
+func TestSubmitJobBadRequest(t *testing.T) {
+	ts := newTestServer(t, pdftoolboxtest.NewExecutor())
+
+	tests := map[string]struct {
+		fields map[string][]string
+		files  map[string]string
+	}{
+		"no files":        {fields: map[string][]string{"profile": {"fix.kfpx"}}},
+		"escaped profile": {fields: map[string][]string{"profile": {"../etc/fix.kfpx"}}, files: map[string]string{"in.pdf": "x"}},
+		"bad variable":    {fields: map[string][]string{"profile": {"fix.kfpx"}, "var": {"nokey"}}, files: map[string]string{"in.pdf": "x"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := upload(t, ts.URL+"/jobs", tc.fields, tc.files)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			body := decode[map[string]server.ErrorInfo](t, resp)
+			assert.NotEmpty(t, body["error"].Message)
+		})
+	}
+}
+
+func TestQuickCheck(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\n",
+		Run:    writeOutputFile(`{"document": {"page_count": 3}}`),
+	}
+
+	ts := newTestServer(t, exe)
+
+	resp := upload(t, ts.URL+"/quickcheck", nil, map[string]string{"in.pdf": "%PDF-1.7"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	qc := decode[pdftoolbox.QuickCheckResponse](t, resp)
+	assert.Equal(t, 3, qc.Document.PageCount)
+
+	// The quick check took a seat of the pool
+	resp, err := http.Get(ts.URL + "/metrics")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1.0, decode[map[string]any](t, resp)["completed"])
+	}
+}
+
+func TestJobOutputJSON(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout:   "ProcessID\t1\nHit\tError\tTransparency used\nError\t1002\tFile or folder not found\n",
+		ExitCode: 102,
+	}
+
+	ts := newTestServer(t, exe)
+
+	resp := upload(t, ts.URL+"/jobs", map[string][]string{"profile": {"fix.kfpx"}}, map[string]string{"in.pdf": "%PDF-1.7"})
+	id := decode[server.JobInfo](t, resp).ID
+	waitForJob(t, ts, id)
+
+	resp, err := http.Get(ts.URL + "/jobs/" + id)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	info := decode[map[string]any](t, resp)
+
+	// Failed runs keep their output, with camelCase keys and errors as text
+	output, ok := info["output"].(map[string]any)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	assert.Contains(t, output, "durationMs")
+	assert.Contains(t, output, "raw")
+	assert.NotContains(t, output, "Duration")
+	if attempts, ok := output["attempts"].([]any); assert.True(t, ok) && assert.Len(t, attempts, 1) {
+		assert.Contains(t, attempts[0].(map[string]any)["error"], "File or folder not found")
+	}
+}
+
+func TestJobRunningBeforeOutput(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n", Delay: 300 * time.Millisecond}
+
+	ts := newTestServer(t, exe)
+
+	resp := upload(t, ts.URL+"/jobs", map[string][]string{"profile": {"fix.kfpx"}}, map[string]string{"in.pdf": "%PDF-1.7"})
+	id := decode[server.JobInfo](t, resp).ID
+
+	// pdfToolbox has printed nothing yet, but the job left the queue
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get(ts.URL + "/jobs/" + id)
+		if err != nil {
+			return false
+		}
+		return decode[server.JobInfo](t, resp).Status == server.JobRunning
+	}, 250*time.Millisecond, 5*time.Millisecond)
+
+	assert.Equal(t, server.JobSucceeded, waitForJob(t, ts, id).Status)
+}
+
+func TestImagesJob(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{
+		Stdout: "ProcessID\t1\n",
+		OutputFiles: map[string][]byte{
+			"in_1.png": []byte("\x89PNG"),
+			"in_2.png": []byte("\x89PNG"),
+		},
+	}
+
+	ts := newTestServer(t, exe)
+
+	resp := upload(t, ts.URL+"/images", map[string][]string{"pages": {"3-4"}, "resolution": {"72"}}, map[string]string{"in.pdf": "%PDF-1.7"})
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	info := waitForJob(t, ts, decode[server.JobInfo](t, resp).ID)
+	assert.Equal(t, server.JobImages, info.Kind)
+	assert.Equal(t, server.JobSucceeded, info.Status)
+	assert.Len(t, info.Artifacts, 2)
+	assert.Equal(t, []any{
+		map[string]any{"page": 3.0, "name": "in_1.png"},
+		map[string]any{"page": 4.0, "name": "in_2.png"},
+	}, info.Result)
+	assert.Contains(t, exe.LastCall(), "--pagerange=3-4")
+}
+
+func TestCompareJobNeedsBothFiles(t *testing.T) {
+	ts := newTestServer(t, pdftoolboxtest.NewExecutor())
+
+	resp := upload(t, ts.URL+"/compare", nil, map[string]string{"a.pdf": "%PDF-1.7"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestVersion(t *testing.T) {
+	ts := newTestServer(t, pdftoolboxtest.NewExecutor())
+
+	resp, err := http.Get(ts.URL + "/version")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	v := decode[pdftoolbox.Version](t, resp)
+	assert.Equal(t, 15, v.Major)
+}
+
+func TestMergeJobKeepsUploadOrder(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n", Run: writeOutputFile("%PDF-1.7")}
+
+	ts := newTestServer(t, exe)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, f := range []struct{ field, name string }{
+		{"file", "cover.pdf"},
+		{"extra", "body.pdf"},
+		{"file", "appendix.pdf"},
+	} {
+		fw, _ := mw.CreateFormFile(f.field, f.name)
+		fw.Write([]byte("%PDF-1.7"))
+	}
+	mw.Close()
+
+	resp, err := http.Post(ts.URL+"/merge", mw.FormDataContentType(), &body)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	info := waitForJob(t, ts, decode[server.JobInfo](t, resp).ID)
+	assert.Equal(t, server.JobSucceeded, info.Status)
+	assert.Equal(t, []string{"cover.pdf", "body.pdf", "appendix.pdf"}, info.Inputs)
+
+	var inputs []string
+	for _, a := range exe.LastCall() {
+		if strings.HasSuffix(a, ".pdf") && !strings.HasPrefix(a, "--") {
+			inputs = append(inputs, filepath.Base(a))
+		}
+	}
+	assert.Equal(t, []string{"cover.pdf", "body.pdf", "appendix.pdf"}, inputs)
+}
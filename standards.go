@@ -0,0 +1,151 @@
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Standard is a PDF ISO standard with library profiles in pdfToolbox.
+type Standard string
+
+const (
+	PDFA1b Standard = "PDF/A-1b"
+	PDFA2b Standard = "PDF/A-2b"
+	PDFA3b Standard = "PDF/A-3b"
+	PDFA4  Standard = "PDF/A-4"
+	PDFX1a Standard = "PDF/X-1a"
+	PDFX3  Standard = "PDF/X-3"
+	PDFX4  Standard = "PDF/X-4"
+	PDFUA1 Standard = "PDF/UA-1"
+)
+
+type standardProfile struct {
+	convert  string
+	validate string
+}
+
+// standardProfiles names the pdfToolbox library profiles for each standard.
+// PDF/UA cannot be reached by an automatic conversion, so it only validates.
+var standardProfiles = map[Standard]standardProfile{
+	PDFA1b: {convert: "Convert to PDF/A-1b", validate: "Verify compliance with PDF/A-1b"},
+	PDFA2b: {convert: "Convert to PDF/A-2b", validate: "Verify compliance with PDF/A-2b"},
+	PDFA3b: {convert: "Convert to PDF/A-3b", validate: "Verify compliance with PDF/A-3b"},
+	PDFA4:  {convert: "Convert to PDF/A-4", validate: "Verify compliance with PDF/A-4"},
+	PDFX1a: {convert: "Convert to PDF/X-1a", validate: "Verify compliance with PDF/X-1a"},
+	PDFX3:  {convert: "Convert to PDF/X-3", validate: "Verify compliance with PDF/X-3"},
+	PDFX4:  {convert: "Convert to PDF/X-4", validate: "Verify compliance with PDF/X-4"},
+	PDFUA1: {validate: "Verify compliance with PDF/UA-1"},
+}
+
+var ErrNoProfileFolder = errors.New("pdftoolbox: no profile folder configured")
+
+type StandardOpts struct {
+	// ProfileFolder holds the library profiles. Defaults to
+	// ClientOpts.ProfileFolder.
+	ProfileFolder string
+	// OutputFile is where ConvertTo writes the converted PDF
+	OutputFile string
+	Args       []Arg
+}
+
+type StandardResult struct {
+	Standard Standard
+	// Conforms is true when the run reported no errors
+	Conforms bool
+	Hits     []CmdOutputHitLine
+	// OutputPath is the converted file, only set by ConvertTo
+	OutputPath string
+	Output     CmdOutput
+}
+
+// Validate checks input against standard with its library profile.
+func (cl *Client) Validate(ctx context.Context, standard Standard, input string, opts *StandardOpts) (*StandardResult, error) {
+	sp, ok := standardProfiles[standard]
+	if !ok {
+		return nil, fmt.Errorf("pdftoolbox: unknown standard %q", standard)
+	}
+
+	return cl.runStandard(ctx, standard, sp.validate, input, opts)
+}
+
+// ConvertTo converts input to standard with its library profile.
+func (cl *Client) ConvertTo(ctx context.Context, standard Standard, input string, opts *StandardOpts) (*StandardResult, error) {
+	sp, ok := standardProfiles[standard]
+	if !ok {
+		return nil, fmt.Errorf("pdftoolbox: unknown standard %q", standard)
+	}
+	if sp.convert == "" {
+		return nil, fmt.Errorf("pdftoolbox: conversion to %s is not available", standard)
+	}
+
+	res, err := cl.runStandard(ctx, standard, sp.convert, input, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Output.Artifacts) > 0 {
+		res.OutputPath = res.Output.Artifacts[0].Path
+	} else if opts != nil {
+		res.OutputPath = opts.OutputFile
+	}
+
+	return res, nil
+}
+
+func (cl *Client) runStandard(ctx context.Context, standard Standard, profileName string, input string, opts *StandardOpts) (*StandardResult, error) {
+	if opts == nil {
+		opts = &StandardOpts{}
+	}
+
+	folder := opts.ProfileFolder
+	if folder == "" {
+		if cl.profileFolder == nil {
+			return nil, ErrNoProfileFolder
+		}
+		folder = *cl.profileFolder
+	}
+
+	profilePath, err := cl.findProfile(ctx, folder, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]Arg(nil), opts.Args...)
+	if opts.OutputFile != "" {
+		args = append(args, NewOutputFileArg(opts.OutputFile))
+	}
+
+	out, err := cl.RunProfileContext(ctx, profilePath, []string{input}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &StandardResult{
+		Standard: standard,
+		Hits:     out.Hits,
+		Output:   out,
+		Conforms: out.Summary.Errors == 0,
+	}
+	for _, h := range out.Hits {
+		if Severity(h.Severity) == SeverityError {
+			res.Conforms = false
+		}
+	}
+
+	return res, nil
+}
+
+// findProfile returns the path of the profile called name in folder.
+func (cl *Client) findProfile(ctx context.Context, folder string, name string) (string, error) {
+	catalog, err := cl.Catalog(folder)
+	if err != nil {
+		return "", err
+	}
+
+	p, err := catalog.Lookup(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return p.Path, nil
+}
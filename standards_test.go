@@ -0,0 +1,109 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// profileLibrary writes the standard profiles used by the tests to a new
+// folder.
+func profileLibrary(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeProfile(t, filepath.Join(dir, "Convert to PDF-X-4.kfpx"), "Convert to PDF/X-4")
+	writeProfile(t, filepath.Join(dir, "Verify PDF-A-2b.kfpx"), "Verify compliance with PDF/A-2b")
+	return dir
+}
+
+func TestValidate(t *testing.T) {
+	folder := profileLibrary(t)
+
+	var enumerated [][]string
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Enqueue(
+		enumerateFolder(&enumerated),
+		pdftoolboxtest.Response{
+			Stdout:   "Hit\tError\tTransparency used\nSummary\tErrors\t1\n",
+			ExitCode: 3,
+		},
+		pdftoolboxtest.Response{Stdout: "Summary\tErrors\t0\n"},
+	)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe, ProfileFolder: &folder})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.Validate(context.Background(), pdftoolbox.PDFA2b, "in.pdf", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.False(t, res.Conforms)
+	assert.Len(t, res.Hits, 1)
+	assert.Equal(t, []string{filepath.Join(folder, "Verify PDF-A-2b.kfpx"), "in.pdf"}, exe.LastCall())
+
+	// The profiles are looked up in the client's catalog, which only
+	// enumerates the folder again when it changes
+	res, err = cl.Validate(context.Background(), pdftoolbox.PDFA2b, "other.pdf", nil)
+	if assert.NoError(t, err) {
+		assert.True(t, res.Conforms)
+	}
+	assert.Len(t, enumerated, 1)
+}
+
+func TestConvertTo(t *testing.T) {
+	folder := profileLibrary(t)
+
+	var enumerated [][]string
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Enqueue(
+		enumerateFolder(&enumerated),
+		pdftoolboxtest.Response{Stdout: "Fix\tConvert to PDF/X-4\nSummary\tCorrections\t3\nOutput\t/work/out.pdf\n"},
+	)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	res, err := cl.ConvertTo(context.Background(), pdftoolbox.PDFX4, "in.pdf", &pdftoolbox.StandardOpts{
+		ProfileFolder: folder,
+		OutputFile:    "/work/out.pdf",
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.True(t, res.Conforms)
+	assert.Equal(t, "/work/out.pdf", res.OutputPath)
+	assert.Equal(t, []string{"--outputfile=/work/out.pdf", filepath.Join(folder, "Convert to PDF-X-4.kfpx"), "in.pdf"}, exe.LastCall())
+}
+
+func TestStandardErrors(t *testing.T) {
+	folder := profileLibrary(t)
+
+	var enumerated [][]string
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = enumerateFolder(&enumerated)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.Validate(context.Background(), pdftoolbox.PDFA2b, "in.pdf", nil)
+	assert.ErrorIs(t, err, pdftoolbox.ErrNoProfileFolder)
+
+	_, err = cl.ConvertTo(context.Background(), pdftoolbox.PDFUA1, "in.pdf", nil)
+	assert.ErrorContains(t, err, "not available")
+
+	_, err = cl.Validate(context.Background(), pdftoolbox.PDFX1a, "in.pdf", &pdftoolbox.StandardOpts{ProfileFolder: folder})
+	assert.ErrorIs(t, err, pdftoolbox.ErrProfileNotFound)
+}
@@ -2,15 +2,27 @@ package pdftoolbox
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
 type LineOutputType string
 
 const (
-	IdentityLine LineOutputType = "identity"
-	ErrorLine    LineOutputType = "error"
-	DurationLine LineOutputType = "duration"
+	IdentityLine  LineOutputType = "identity"
+	ErrorLine     LineOutputType = "error"
+	DurationLine  LineOutputType = "duration"
+	ProcessIDLine LineOutputType = "processId"
+	ProfileLine   LineOutputType = "profile"
+	InputLine     LineOutputType = "input"
+	PagesLine     LineOutputType = "pages"
+	ProgressLine  LineOutputType = "progress"
+	VariableLine  LineOutputType = "variable"
+	HitLine       LineOutputType = "hit"
+	FixLine       LineOutputType = "fix"
+	SummaryLine   LineOutputType = "summary"
+	FinishedLine  LineOutputType = "finished"
+	DispatchLine  LineOutputType = "dispatch"
 )
 
 type CmdOutputLine interface {
@@ -18,9 +30,82 @@ type CmdOutputLine interface {
 	String() string
 }
 
-type LineOutputTypename struct {
-	json.RawMessage
-	Typename LineOutputType `json:"__typename"`
+// CmdOutputLines is a list of parsed lines that can be unmarshalled back into
+// their concrete types using the __typename field.
+type CmdOutputLines []CmdOutputLine
+
+func (ls *CmdOutputLines) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if raw == nil {
+		*ls = nil
+		return nil
+	}
+
+	lines := make(CmdOutputLines, 0, len(raw))
+	for _, r := range raw {
+		l, err := unmarshalLine(r)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, l)
+	}
+
+	*ls = lines
+	return nil
+}
+
+func unmarshalLine(b []byte) (CmdOutputLine, error) {
+	var head struct {
+		Typename LineOutputType `json:"__typename"`
+	}
+	if err := json.Unmarshal(b, &head); err != nil {
+		return nil, err
+	}
+
+	switch head.Typename {
+	case IdentityLine:
+		return unmarshalLineAs[CmdOutputIdentityLine](b)
+	case ErrorLine:
+		return unmarshalLineAs[CmdOutputErrorLine](b)
+	case DurationLine:
+		return unmarshalLineAs[CmdOutputDurationLine](b)
+	case ProcessIDLine:
+		return unmarshalLineAs[CmdOutputProcessIDLine](b)
+	case ProfileLine:
+		return unmarshalLineAs[CmdOutputProfileLine](b)
+	case InputLine:
+		return unmarshalLineAs[CmdOutputInputLine](b)
+	case PagesLine:
+		return unmarshalLineAs[CmdOutputPagesLine](b)
+	case ProgressLine:
+		return unmarshalLineAs[CmdOutputProgressLine](b)
+	case VariableLine:
+		return unmarshalLineAs[CmdOutputVariableLine](b)
+	case HitLine:
+		return unmarshalLineAs[CmdOutputHitLine](b)
+	case FixLine:
+		return unmarshalLineAs[CmdOutputFixLine](b)
+	case SummaryLine:
+		return unmarshalLineAs[CmdOutputSummaryLine](b)
+	case FinishedLine:
+		return unmarshalLineAs[CmdOutputFinishedLine](b)
+	case DispatchLine:
+		return unmarshalLineAs[CmdOutputDispatchLine](b)
+	}
+
+	return nil, fmt.Errorf("pdftoolbox: unknown line type %q", head.Typename)
+}
+
+func unmarshalLineAs[T CmdOutputLine](b []byte) (CmdOutputLine, error) {
+	var l T
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, err
+	}
+	return l, nil
 }
 
 type CmdOutputIdentityLine struct {
@@ -37,9 +122,11 @@ func (l CmdOutputIdentityLine) String() string {
 	return l.Line
 }
 
-func (l *CmdOutputIdentityLine) MarshalJSON() (b []byte, e error) {
-	l.Typename = l.Type()
-	return json.Marshal(l)
+func (l CmdOutputIdentityLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputIdentityLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
 }
 
 type CmdOutputDurationLine struct {
@@ -61,9 +148,28 @@ func (l CmdOutputDurationLine) Duration() time.Duration {
 	return l.dur
 }
 
-func (l *CmdOutputDurationLine) MarshalJSON() (b []byte, e error) {
-	l.Typename = l.Type()
-	return json.Marshal(l)
+func (l CmdOutputDurationLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputDurationLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON restores the duration from Parts, as it is not serialized on
+// its own.
+func (l *CmdOutputDurationLine) UnmarshalJSON(b []byte) error {
+	type alias CmdOutputDurationLine
+	var a alias
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	*l = CmdOutputDurationLine(a)
+	if len(l.Parts) > 1 {
+		l.dur = parseDuration(l.Parts[1])
+	}
+
+	return nil
 }
 
 type CmdOutputErrorLine struct {
@@ -80,85 +186,328 @@ func (l CmdOutputErrorLine) String() string {
 	return l.Message
 }
 
-func (l *CmdOutputErrorLine) MarshalJSON() (b []byte, e error) {
-	l.Typename = l.Type()
-	return json.Marshal(l)
+func (l CmdOutputErrorLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputErrorLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputProcessIDLine is the `ProcessID	<pid>` line printed first by every run.
+type CmdOutputProcessIDLine struct {
+	Typename  LineOutputType `json:"__typename"`
+	Line      string         `json:"line"`
+	Parts     []string       `json:"parts"`
+	ProcessID int            `json:"processId"`
+}
+
+func (l CmdOutputProcessIDLine) Type() LineOutputType {
+	return ProcessIDLine
+}
+
+func (l CmdOutputProcessIDLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputProcessIDLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputProcessIDLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputProfileLine is the `Profile	<path>` line naming the profile being run.
+type CmdOutputProfileLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Path     string         `json:"path"`
+}
+
+func (l CmdOutputProfileLine) Type() LineOutputType {
+	return ProfileLine
+}
+
+func (l CmdOutputProfileLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputProfileLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputProfileLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputInputLine is the `Input	<path>` line naming the file being processed.
+type CmdOutputInputLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Path     string         `json:"path"`
+}
+
+func (l CmdOutputInputLine) Type() LineOutputType {
+	return InputLine
+}
+
+func (l CmdOutputInputLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputInputLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputInputLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputPagesLine is the `Pages	<n>` line with the page count of the input.
+type CmdOutputPagesLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Pages    int            `json:"pages"`
+}
+
+func (l CmdOutputPagesLine) Type() LineOutputType {
+	return PagesLine
+}
+
+func (l CmdOutputPagesLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputPagesLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputPagesLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputProgressLine is a `Progress	<n>	%` line.
+type CmdOutputProgressLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Percent  int            `json:"percent"`
+}
+
+func (l CmdOutputProgressLine) Type() LineOutputType {
+	return ProgressLine
+}
+
+func (l CmdOutputProgressLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputProgressLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputProgressLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputVariableLine is a `Variable	<name>	<value>` line reporting the
+// value a profile variable resolved to.
+type CmdOutputVariableLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Name     string         `json:"name"`
+	Value    string         `json:"value"`
+}
+
+func (l CmdOutputVariableLine) Type() LineOutputType {
+	return VariableLine
+}
+
+func (l CmdOutputVariableLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputVariableLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputVariableLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputHitLine is a `Hit	<severity>	<message>` line reported by a check.
+type CmdOutputHitLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Severity string         `json:"severity"`
+	Message  string         `json:"message"`
+}
+
+func (l CmdOutputHitLine) Type() LineOutputType {
+	return HitLine
+}
+
+func (l CmdOutputHitLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputHitLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputHitLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputFixLine is a `Fix	<name>` line reported when a fixup was applied.
+type CmdOutputFixLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Name     string         `json:"name"`
+}
+
+func (l CmdOutputFixLine) Type() LineOutputType {
+	return FixLine
+}
+
+func (l CmdOutputFixLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputFixLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputFixLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputSummaryLine is a `Summary	<kind>	<count>` line, where kind is one
+// of Corrections, Errors, Warnings or Infos.
+type CmdOutputSummaryLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Kind     string         `json:"kind"`
+	Count    int            `json:"count"`
+}
+
+func (l CmdOutputSummaryLine) Type() LineOutputType {
+	return SummaryLine
+}
+
+func (l CmdOutputSummaryLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputSummaryLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputSummaryLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputFinishedLine is the `Finished	<path>` line printed once an input
+// file has been processed.
+type CmdOutputFinishedLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	Path     string         `json:"path"`
+}
+
+func (l CmdOutputFinishedLine) Type() LineOutputType {
+	return FinishedLine
+}
+
+func (l CmdOutputFinishedLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputFinishedLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputFinishedLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
+}
+
+// CmdOutputDispatchLine is a `Dispatcher	<address>	<message>` or
+// `Satellite	<address>	<message>` line printed when a job is run with
+// --dist.
+type CmdOutputDispatchLine struct {
+	Typename LineOutputType `json:"__typename"`
+	Line     string         `json:"line"`
+	Parts    []string       `json:"parts"`
+	// Role is either "Dispatcher" or "Satellite"
+	Role    string `json:"role"`
+	Address string `json:"address"`
+	Message string `json:"message"`
+}
+
+func (l CmdOutputDispatchLine) Type() LineOutputType {
+	return DispatchLine
+}
+
+func (l CmdOutputDispatchLine) String() string {
+	return l.Line
+}
+
+func (l CmdOutputDispatchLine) MarshalJSON() ([]byte, error) {
+	type alias CmdOutputDispatchLine
+	a := alias(l)
+	a.Typename = l.Type()
+	return json.Marshal(a)
 }
 
 type CmdStepOutput struct {
-	Name            string          `json:"name"`
-	Lines           []CmdOutputLine `json:"-"` // TODO: add serialization
-	OutputFilePaths []string        `json:"outputFilePaths"`
+	Name            string             `json:"name"`
+	Lines           CmdOutputLines     `json:"lines"`
+	OutputFilePaths []string           `json:"outputFilePaths"`
+	Summary         Summary            `json:"summary"`
+	Hits            []CmdOutputHitLine `json:"hits,omitempty"`
+	Fixes           []CmdOutputFixLine `json:"fixes,omitempty"`
 }
 
-func (ce *CmdStepOutput) UnmarshalJSON(b []byte) error {
-	if b == nil {
-		return nil
-	}
+type Verdict string
 
-	var objMap map[string]*json.RawMessage
-	err := json.Unmarshal(b, &objMap)
-	if err != nil {
-		return err
-	}
+const (
+	VerdictPass     Verdict = "pass"
+	VerdictFixed    Verdict = "fixed"
+	VerdictWarnings Verdict = "warnings"
+	VerdictErrors   Verdict = "errors"
+)
 
-	for k, rawMessage := range objMap {
-		if rawMessage == nil {
-			continue
-		}
+// Summary holds the counts of the `Summary` lines of a run or step.
+type Summary struct {
+	Corrections int `json:"corrections"`
+	Errors      int `json:"errors"`
+	Warnings    int `json:"warnings"`
+	Infos       int `json:"infos"`
+}
 
-		switch k {
-		case "name":
-			err := json.Unmarshal(*rawMessage, &ce.Name)
-			if err != nil {
-				return err
-			}
-		case "lines":
-			var lines []LineOutputTypename
-			err := json.Unmarshal(*rawMessage, &lines)
-			if err != nil {
-				return err
-			}
-
-			var finalLines []CmdOutputLine
-
-			for _, line := range lines {
-				switch line.Typename {
-				case ErrorLine:
-					var el CmdOutputErrorLine
-					err := json.Unmarshal(line.RawMessage, &el)
-					if err != nil {
-						return err
-					}
-
-					finalLines = append(finalLines, el)
-				case IdentityLine:
-					var el CmdOutputIdentityLine
-					err := json.Unmarshal(line.RawMessage, &el)
-					if err != nil {
-						return err
-					}
-
-					finalLines = append(finalLines, el)
-				case DurationLine:
-					var el CmdOutputDurationLine
-					err := json.Unmarshal(line.RawMessage, &el)
-					if err != nil {
-						return err
-					}
-
-					finalLines = append(finalLines, el)
-				}
-			}
-		case "outputFilePaths":
-			err := json.Unmarshal(*rawMessage, &ce.OutputFilePaths)
-			if err != nil {
-				return err
-			}
-		}
+// Verdict reports the most severe outcome of the summary: errors, then
+// warnings, then fixed when corrections were applied, otherwise pass.
+func (s Summary) Verdict() Verdict {
+	switch {
+	case s.Errors > 0:
+		return VerdictErrors
+	case s.Warnings > 0:
+		return VerdictWarnings
+	case s.Corrections > 0:
+		return VerdictFixed
 	}
+	return VerdictPass
+}
 
-	return nil
+func (s *Summary) add(l CmdOutputSummaryLine) {
+	switch l.Kind {
+	case "Corrections":
+		s.Corrections += l.Count
+	case "Errors":
+		s.Errors += l.Count
+	case "Warnings":
+		s.Warnings += l.Count
+	case "Infos":
+		s.Infos += l.Count
+	}
 }
 
 type EnumerateProfilesResponse struct {
@@ -9,7 +9,6 @@ import (
 )
 
 func TestDeserialize(t *testing.T) {
-	t.Skip()
 	stepOutput := pdftoolbox.CmdStepOutput{
 		Name: "x",
 		Lines: []pdftoolbox.CmdOutputLine{
@@ -17,7 +16,7 @@ func TestDeserialize(t *testing.T) {
 		},
 	}
 
-	expected := `{"name":"x","lines":[{"__typename":"identity","line":"my line","parts":null}],"outputFilePaths":null}`
+	expected := `{"name":"x","lines":[{"__typename":"identity","line":"my line","parts":null}],"outputFilePaths":null,"summary":{"corrections":0,"errors":0,"warnings":0,"infos":0}}`
 
 	b, err := json.Marshal(stepOutput)
 	assert.NoError(t, err)
@@ -26,5 +25,53 @@ func TestDeserialize(t *testing.T) {
 	var so pdftoolbox.CmdStepOutput
 	err = json.Unmarshal(b, &so)
 	assert.NoError(t, err)
+	assert.Equal(t, stepOutput, so)
+}
+
+func TestLinesRoundTrip(t *testing.T) {
+	output := `ProcessID	13913
+Profile	/opt/impose/profiles/StickerIt_CLI_Example.kfpx
+Input	/opt/impose/work/SA41271-1UF-R-FL5EZ9QY.pdf
+Pages	1
+Progress	39	%
+Variable	trimHeight	70
+Hit	Error	Trim box is not equal to 70 x 70 mm
+Fix	Indigo-MotionCutter_MediaBox
+Errors	1	Trim box is not equal to 70 x 70 mm
+Summary	Errors	1
+Step	Create PDF copy
+Finished	/opt/impose/work/SA41271-1UF-R-FL5EZ9QY.pdf
+Duration	01:07`
+
+	parsed, err := pdftoolbox.ParseOutput(output)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(parsed.Lines)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var lines pdftoolbox.CmdOutputLines
+	err = json.Unmarshal(b, &lines)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	if !assert.Len(t, lines, len(parsed.Lines)) {
+		t.FailNow()
+	}
+	for i, l := range lines {
+		assert.Equal(t, parsed.Lines[i].Type(), l.Type())
+		assert.Equal(t, parsed.Lines[i].String(), l.String())
+	}
+
+	dur := lines[12].(pdftoolbox.CmdOutputDurationLine)
+	assert.Equal(t, parsed.Duration, dur.Duration())
+}
 
+func TestSummaryVerdict(t *testing.T) {
+	assert.Equal(t, pdftoolbox.VerdictPass, pdftoolbox.Summary{Infos: 2}.Verdict())
+	assert.Equal(t, pdftoolbox.VerdictFixed, pdftoolbox.Summary{Corrections: 1, Infos: 2}.Verdict())
+	assert.Equal(t, pdftoolbox.VerdictWarnings, pdftoolbox.Summary{Corrections: 1, Warnings: 1}.Verdict())
+	assert.Equal(t, pdftoolbox.VerdictErrors, pdftoolbox.Summary{Errors: 1, Warnings: 1}.Verdict())
 }
@@ -0,0 +1,193 @@
+package pdftoolbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type VariableProblemKind string
+
+const (
+	// VariableUnknown is a variable the profile does not declare.
+	VariableUnknown VariableProblemKind = "unknown"
+	// VariableMissing is a declared variable without a default that was not
+	// set. Profiles do not mark variables as required; a variable counts as
+	// required when its Value, the default reported by EnumerateProfiles, is
+	// null.
+	VariableMissing VariableProblemKind = "missing"
+	// VariableTypeMismatch is a value that does not parse as the declared
+	// type.
+	VariableTypeMismatch VariableProblemKind = "type_mismatch"
+)
+
+type VariableProblem struct {
+	Key  string              `json:"key"`
+	Kind VariableProblemKind `json:"kind"`
+	// Type and Value are set for type mismatches
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+func (p VariableProblem) String() string {
+	switch p.Kind {
+	case VariableUnknown:
+		return fmt.Sprintf("unknown variable %q", p.Key)
+	case VariableMissing:
+		return fmt.Sprintf("missing required variable %q", p.Key)
+	case VariableTypeMismatch:
+		return fmt.Sprintf("variable %q is not a %s: %q", p.Key, p.Type, p.Value)
+	}
+	return fmt.Sprintf("variable %q: %s", p.Key, p.Kind)
+}
+
+// VariablesError is returned by RunProfile when ClientOpts.ValidateVariables
+// is set and the --setvariable args do not match the profile's Variables.
+type VariablesError struct {
+	Profile  string
+	Problems []VariableProblem
+}
+
+func (e *VariablesError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return fmt.Sprintf("pdftoolbox: invalid variables for %s: %s", e.Profile, strings.Join(msgs, "; "))
+}
+
+// ValidateVariables checks the --setvariable args in args against the
+// variables declared by profile. It returns a *VariablesError listing every
+// problem found, or nil.
+func ValidateVariables(profile Profiles, args []Arg) error {
+	set := make(map[string]string)
+	var keys []string
+	for _, a := range args {
+		if a.Arg != "--setvariable" || a.Value == nil {
+			continue
+		}
+		key, value, _ := strings.Cut(*a.Value, ":")
+		if _, ok := set[key]; !ok {
+			keys = append(keys, key)
+		}
+		set[key] = value
+	}
+
+	declared := make(map[string]Variables, len(profile.Variables))
+	for _, v := range profile.Variables {
+		declared[v.Key] = v
+	}
+
+	var problems []VariableProblem
+	for _, key := range keys {
+		v, ok := declared[key]
+		if !ok {
+			problems = append(problems, VariableProblem{Key: key, Kind: VariableUnknown})
+			continue
+		}
+		if !matchesType(v.Type, set[key]) {
+			problems = append(problems, VariableProblem{Key: key, Kind: VariableTypeMismatch, Type: v.Type, Value: set[key]})
+		}
+	}
+
+	for _, v := range profile.Variables {
+		if _, ok := set[v.Key]; !ok && v.Value == nil {
+			problems = append(problems, VariableProblem{Key: v.Key, Kind: VariableMissing})
+		}
+	}
+
+	if len(problems) > 0 {
+		name := profile.Name
+		if name == "" {
+			name = profile.Path
+		}
+		return &VariablesError{Profile: name, Problems: problems}
+	}
+
+	return nil
+}
+
+// matchesType reports whether value can be used for a variable of type typ.
+// Unknown types accept any value.
+func matchesType(typ string, value string) bool {
+	switch strings.ToLower(typ) {
+	case "number":
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case "boolean":
+		return value == "true" || value == "false"
+	case "list":
+		// Either a JSON array or comma separated items
+		if strings.HasPrefix(strings.TrimSpace(value), "[") {
+			var items []any
+			return json.Unmarshal([]byte(value), &items) == nil
+		}
+		return true
+	}
+	return true
+}
+
+// validateVariables checks args against the variables of profile. Profiles
+// in the client's profile folder are looked up in its catalog; any other
+// profile is enumerated on its own, so that one-off paths do not each keep a
+// catalog alive.
+func (cl *Client) validateVariables(ctx context.Context, profile string, args []Arg) error {
+	path := profile
+	if cl.profileFolder != nil && filepath.IsLocal(profile) {
+		path = filepath.Join(*cl.profileFolder, profile)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	if cl.inProfileFolder(abs) {
+		catalog, err := cl.Catalog("")
+		if err != nil {
+			return err
+		}
+
+		p, err := catalog.Lookup(ctx, abs)
+		if err != nil {
+			return err
+		}
+		return ValidateVariables(p, args)
+	}
+
+	if _, err := os.Stat(abs); errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %q", ErrProfileNotFound, profile)
+	}
+
+	resp, err := cl.enumerateFiles(ctx, []string{abs})
+	if err != nil {
+		return err
+	}
+	for _, p := range resp.Profiles {
+		if p.Path == abs {
+			return ValidateVariables(p, args)
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrProfileNotFound, profile)
+}
+
+// inProfileFolder reports whether the absolute path is inside the client's
+// profile folder.
+func (cl *Client) inProfileFolder(path string) bool {
+	if cl.profileFolder == nil {
+		return false
+	}
+
+	folder, err := filepath.Abs(*cl.profileFolder)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(folder, path)
+	return err == nil && filepath.IsLocal(rel)
+}
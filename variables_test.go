@@ -0,0 +1,144 @@
+package pdftoolbox_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var trimVariables = []pdftoolbox.Variables{
+	{Key: "trimWidth", Type: "number"},
+	{Key: "trimHeight", Type: "number", Value: 297.0},
+	{Key: "bleed", Type: "boolean", Value: true},
+	{Key: "cutlineName", Type: "string", Value: "CutContour"},
+	{Key: "pages", Type: "list", Value: []any{1.0}},
+}
+
+func TestValidateVariables(t *testing.T) {
+	profile := pdftoolbox.Profiles{Name: "Trim", Variables: trimVariables}
+
+	tests := map[string]struct {
+		args []pdftoolbox.Arg
+		want []pdftoolbox.VariableProblem
+	}{
+		"valid": {
+			args: []pdftoolbox.Arg{
+				pdftoolbox.NewSetVariableArg("trimWidth", 210),
+				pdftoolbox.NewSetVariableArg("bleed", false),
+				pdftoolbox.NewSetVariableArg("cutlineName", "Die Cut"),
+				pdftoolbox.NewSetVariableArg("pages", "[1, 2]"),
+				pdftoolbox.NewTimeoutArg(0),
+			},
+		},
+		"missing": {
+			want: []pdftoolbox.VariableProblem{{Key: "trimWidth", Kind: pdftoolbox.VariableMissing}},
+		},
+		"unknown and mismatched": {
+			args: []pdftoolbox.Arg{
+				pdftoolbox.NewSetVariableArg("trimWidth", "wide"),
+				pdftoolbox.NewSetVariableArg("bleed", "1"),
+				pdftoolbox.NewSetVariableArg("pages", "[1,"),
+				pdftoolbox.NewSetVariableArg("colour", "cyan"),
+			},
+			want: []pdftoolbox.VariableProblem{
+				{Key: "trimWidth", Kind: pdftoolbox.VariableTypeMismatch, Type: "number", Value: "wide"},
+				{Key: "bleed", Kind: pdftoolbox.VariableTypeMismatch, Type: "boolean", Value: "1"},
+				{Key: "pages", Kind: pdftoolbox.VariableTypeMismatch, Type: "list", Value: "[1,"},
+				{Key: "colour", Kind: pdftoolbox.VariableUnknown},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := pdftoolbox.ValidateVariables(profile, tt.args)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var ve *pdftoolbox.VariablesError
+			if assert.True(t, errors.As(err, &ve)) {
+				assert.Equal(t, "Trim", ve.Profile)
+				assert.Equal(t, tt.want, ve.Problems)
+			}
+			assert.True(t, pdftoolbox.IsInputError(err))
+		})
+	}
+}
+
+func TestRunProfileValidatesVariables(t *testing.T) {
+	dir := t.TempDir()
+	writeProfile(t, filepath.Join(dir, "trim.kfpx"), "Trim")
+
+	var enumerated [][]string
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = enumerateFolder(&enumerated, trimVariables...)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor:          exe,
+		ProfileFolder:     &dir,
+		ValidateVariables: true,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.RunProfile("trim.kfpx", []string{"in.pdf"}, pdftoolbox.NewSetVariableArg("trimWidht", 210))
+
+	var ve *pdftoolbox.VariablesError
+	if assert.True(t, errors.As(err, &ve)) {
+		assert.Equal(t, []pdftoolbox.VariableProblem{
+			{Key: "trimWidht", Kind: pdftoolbox.VariableUnknown},
+			{Key: "trimWidth", Kind: pdftoolbox.VariableMissing},
+		}, ve.Problems)
+	}
+	// Only the version check and the enumeration ran, pdfToolbox was not
+	// started for the job
+	assert.Len(t, exe.Calls(), 2)
+
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n"}
+	_, err = cl.RunProfile("trim.kfpx", []string{"in.pdf"}, pdftoolbox.NewSetVariableArg("trimWidth", 210))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--setvariable=trimWidth:210", filepath.Join(dir, "trim.kfpx"), "in.pdf"}, exe.LastCall())
+	assert.Len(t, enumerated, 1)
+}
+
+func TestRunProfileValidatesVariablesOutsideProfileFolder(t *testing.T) {
+	dir, other := t.TempDir(), t.TempDir()
+	writeProfile(t, filepath.Join(other, "trim.kfpx"), "Trim")
+	writeProfile(t, filepath.Join(other, "unrelated.kfpx"), "Unrelated")
+
+	var enumerated [][]string
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Default = enumerateFolder(&enumerated, trimVariables...)
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{
+		Executor:          exe,
+		ProfileFolder:     &dir,
+		ValidateVariables: true,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = cl.RunProfile(filepath.Join(other, "trim.kfpx"), []string{"in.pdf"}, pdftoolbox.NewSetVariableArg("bleed", "yes"))
+
+		var ve *pdftoolbox.VariablesError
+		if assert.True(t, errors.As(err, &ve)) {
+			assert.Equal(t, "Trim", ve.Profile)
+		}
+	}
+
+	// Only the profile itself is enumerated, and it is not cached in a
+	// catalog of its folder
+	assert.Equal(t, [][]string{{"Trim"}, {"Trim"}}, enumerated)
+
+	_, err = cl.RunProfile(filepath.Join(other, "missing.kfpx"), []string{"in.pdf"}, pdftoolbox.NewSetVariableArg("trimWidth", 210))
+	assert.ErrorIs(t, err, pdftoolbox.ErrProfileNotFound)
+}
@@ -0,0 +1,170 @@
+package pdftoolbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var ErrUnsupported = errors.New("pdftoolbox: not supported by the installed version")
+
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Build int
+	Raw   string
+}
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// ParseVersion finds the first version number in s, e.g. in
+// "callas pdfToolbox CLI 15.1.639".
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("pdftoolbox: no version found in %q", s)
+	}
+
+	v := Version{Raw: m[0]}
+	parts := []*int{&v.Major, &v.Minor, &v.Patch, &v.Build}
+	for i, p := range parts {
+		if m[i+1] != "" {
+			*p, _ = strconv.Atoi(m[i+1])
+		}
+	}
+
+	return v, nil
+}
+
+func (v Version) String() string {
+	return v.Raw
+}
+
+// Compare returns -1, 0 or 1 when v is older than, the same as or newer
+// than o.
+func (v Version) Compare(o Version) int {
+	a := []int{v.Major, v.Minor, v.Patch, v.Build}
+	b := []int{o.Major, o.Minor, o.Patch, o.Build}
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v Version) AtLeast(o Version) bool {
+	return v.Compare(o) >= 0
+}
+
+type Capability string
+
+const (
+	CapJSONReport  Capability = "json-report"
+	CapJSONOutput  Capability = "json-output"
+	CapQuickCheck  Capability = "quickcheck"
+	CapSaveAsImage Capability = "saveasimg"
+	CapMergeSplit  Capability = "merge-split"
+	CapCompare     Capability = "compare"
+)
+
+// capabilities lists the first pdfToolbox release supporting each feature.
+// The versions have not been checked against the callas release notes;
+// where one is wrong for an installation, override it with
+// ClientOpts.Capabilities.
+var capabilities = map[Capability]Version{
+	CapJSONReport:  {Major: 12, Raw: "12"},
+	CapJSONOutput:  {Major: 12, Raw: "12"},
+	CapQuickCheck:  {Major: 12, Raw: "12"},
+	CapSaveAsImage: {Major: 9, Raw: "9"},
+	CapMergeSplit:  {Major: 9, Raw: "9"},
+	CapCompare:     {Major: 13, Raw: "13"},
+}
+
+// versionErrorTTL is how long a failed version detection is remembered
+// before --version is run again.
+const versionErrorTTL = 30 * time.Second
+
+// Version detects the pdfToolbox version with --version. A successful
+// result is cached for the lifetime of the client, a failure for
+// versionErrorTTL.
+func (cl *Client) Version(ctx context.Context) (Version, error) {
+	cl.versionMu.Lock()
+	defer cl.versionMu.Unlock()
+
+	if cl.version != nil {
+		return *cl.version, nil
+	}
+	if cl.versionErr != nil && time.Since(cl.versionErrAt) < versionErrorTTL {
+		return Version{}, cl.versionErr
+	}
+
+	v, err := cl.detectVersion(ctx)
+	if err != nil {
+		// A cancelled detection says nothing about the installation
+		var ce *CancelledError
+		if !errors.As(err, &ce) {
+			cl.versionErr, cl.versionErrAt = err, time.Now()
+		}
+		return Version{}, err
+	}
+
+	cl.version, cl.versionErr = &v, nil
+	return v, nil
+}
+
+func (cl *Client) detectVersion(ctx context.Context) (Version, error) {
+	out, err := cl.runCmd(ctx, "--version")
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(out.Raw)
+}
+
+// Supports reports whether the installed pdfToolbox has the capability.
+func (cl *Client) Supports(ctx context.Context, c Capability) (bool, error) {
+	required, ok := cl.capabilities[c]
+	if !ok {
+		return false, fmt.Errorf("pdftoolbox: unknown capability %q", c)
+	}
+
+	v, err := cl.Version(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return v.AtLeast(required), nil
+}
+
+// require returns an error wrapping ErrUnsupported if the installed version
+// lacks c. Like variable validation, the check fails closed: when the
+// version cannot be detected the command is not run and the detection error
+// is returned.
+func (cl *Client) require(ctx context.Context, c Capability) error {
+	ok, err := cl.Supports(ctx, c)
+	if err != nil {
+		return fmt.Errorf("pdftoolbox: cannot check support for %s: %w", c, err)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s needs pdfToolbox %s or later", ErrUnsupported, c, cl.capabilities[c])
+	}
+	return nil
+}
+
+// requireArgs checks the capabilities needed by the args of a profile run.
+// Only a JSON report is gated; long-standing flags such as --setvariable
+// and --dist are passed through without detecting the version.
+func (cl *Client) requireArgs(ctx context.Context, args []Arg) error {
+	if req, ok := reportFromArgs(args); ok && req.format == ReportJSON {
+		return cl.require(ctx, CapJSONReport)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package pdftoolbox_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fikastudio/pdftoolbox-go"
+	"github.com/fikastudio/pdftoolbox-go/pdftoolboxtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := pdftoolbox.ParseVersion("callas pdfToolbox CLI 15.1.639 (x64)")
+	assert.NoError(t, err)
+	assert.Equal(t, pdftoolbox.Version{Major: 15, Minor: 1, Patch: 639, Raw: "15.1.639"}, v)
+
+	older, _ := pdftoolbox.ParseVersion("12.3")
+	assert.True(t, v.AtLeast(older))
+	assert.Equal(t, -1, older.Compare(v))
+
+	_, err = pdftoolbox.ParseVersion("pdfToolbox")
+	assert.Error(t, err)
+}
+
+func TestVersionIsCached(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for range 3 {
+		v, err := cl.Version(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 15, v.Major)
+	}
+	assert.Len(t, exe.Calls(), 1)
+
+	ok, err := cl.Supports(context.Background(), pdftoolbox.CapCompare)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestUnsupportedCapability(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Version = "11.0.400"
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = cl.Compare(context.Background(), "a.pdf", "b.pdf", nil)
+	assert.ErrorIs(t, err, pdftoolbox.ErrUnsupported)
+
+	_, err = cl.RunProfile("profile.kfpx", []string{"in.pdf"}, pdftoolbox.NewReportArg(pdftoolbox.ReportJSON, "/tmp/report.json"))
+	assert.ErrorIs(t, err, pdftoolbox.ErrUnsupported)
+
+	// Only the version was queried, no command was run
+	assert.Equal(t, [][]string{{"--version"}}, exe.Calls())
+}
+
+func TestLongStandingArgsSkipVersion(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Version = ""
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n"}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// Neither run needs the version, so a failing --version does not matter
+	_, err = cl.RunProfile("profile.kfpx", []string{"in.pdf"}, pdftoolbox.NewSetVariableArg("trimWidth", 210))
+	assert.NoError(t, err)
+	_, err = cl.RunProfile("profile.kfpx", []string{"in.pdf"}, pdftoolbox.NewDistArg())
+	assert.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"--setvariable=trimWidth:210", "profile.kfpx", "in.pdf"},
+		{"--dist", "profile.kfpx", "in.pdf"},
+	}, exe.Calls())
+}
+
+func TestVersionDetectionFailsClosed(t *testing.T) {
+	exe := pdftoolboxtest.NewExecutor()
+	exe.Version = ""
+	exe.Default = pdftoolboxtest.Response{Stdout: "ProcessID\t1\n"}
+
+	cl, err := pdftoolbox.New("/tmp/pdftoolbox", &pdftoolbox.ClientOpts{Executor: exe})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for range 2 {
+		_, err = cl.Compare(context.Background(), "a.pdf", "b.pdf", nil)
+		assert.ErrorContains(t, err, "no version found")
+		assert.NotErrorIs(t, err, pdftoolbox.ErrUnsupported)
+	}
+
+	// The failed detection is remembered, Compare itself never ran
+	assert.Equal(t, [][]string{{"--version"}}, exe.Calls())
+}